@@ -6,6 +6,9 @@ import (
 	"discord-bot-template/internal/bot"
 	"discord-bot-template/internal/config"
 	"discord-bot-template/internal/auth"
+	"discord-bot-template/internal/embed"
+	"discord-bot-template/internal/i18n"
+	"discord-bot-template/internal/state"
 )
 
 func main() {
@@ -14,7 +17,18 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
-	auth.Init(cfg)
+	if err := auth.Init(cfg); err != nil {
+		log.Fatalf("Failed to load permissions: %v", err)
+	}
+	if err := state.Init(cfg); err != nil {
+		log.Fatalf("Failed to open state store: %v", err)
+	}
+	if err := embed.InitTemplates(cfg.TemplatesDir, cfg.TemplatesReload); err != nil {
+		log.Fatalf("Failed to load embed templates: %v", err)
+	}
+	if err := i18n.Init(cfg); err != nil {
+		log.Fatalf("Failed to load locales: %v", err)
+	}
 
 	// Create bot instance
 	b, err := bot.New(cfg)
@@ -36,6 +50,9 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to stop bot: %v", err)
 	}
+	if err := state.Close(); err != nil {
+		log.Printf("Failed to close state store: %v", err)
+	}
 
 	log.Println("Bot has been shut down gracefully")
 }
@@ -5,10 +5,12 @@ go 1.21
 require (
 	github.com/bwmarrin/discordgo v0.28.1
 	github.com/sethvargo/go-envconfig v1.1.0
+	go.etcd.io/bbolt v1.3.10
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/gorilla/websocket v1.4.2 // indirect
 	golang.org/x/crypto v0.0.0-20210421170649-83a5a9bb288b // indirect
-	golang.org/x/sys v0.0.0-20201119102817-f84b799fce68 // indirect
+	golang.org/x/sys v0.4.0 // indirect
 )
@@ -0,0 +1,206 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ============================================
+// 伺服器授權（角色 / 用戶 → 權限等級）
+// ============================================
+
+// GuildGrants 是單一伺服器的授權設定
+type GuildGrants struct {
+	Roles map[string]Permission `json:"roles"` // 角色 ID → 權限等級
+	Users map[string]Permission `json:"users"` // 用戶 ID → 權限等級
+}
+
+// GrantStore 保存所有伺服器的授權，並寫入 JSON 檔案
+type GrantStore struct {
+	mu     sync.RWMutex
+	path   string // 空字串 = 只存在記憶體
+	guilds map[string]*GuildGrants
+}
+
+// GrantableLevels 是可以透過伺服器授權給予的等級（Bot Admin / Owner 只能由環境變數設定）
+var GrantableLevels = []Permission{PermissionServerAdmin, PermissionBotModerator}
+
+var grants = &GrantStore{guilds: make(map[string]*GuildGrants)}
+
+// Grants 取得目前的授權資料（需先呼叫 Init）
+func Grants() *GrantStore {
+	return grants
+}
+
+// LoadGrants 從 JSON 檔案讀取授權，檔案不存在時回傳空的資料
+func LoadGrants(path string) (*GrantStore, error) {
+	store := &GrantStore{
+		path:   path,
+		guilds: make(map[string]*GuildGrants),
+	}
+	if path == "" {
+		return store, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, &store.guilds); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return store, nil
+}
+
+// GrantRole 授權角色指定的權限等級
+func (g *GrantStore) GrantRole(guildID, roleID string, level Permission) error {
+	return g.update(guildID, func(gg *GuildGrants) {
+		gg.Roles[roleID] = level
+	})
+}
+
+// GrantUser 授權用戶指定的權限等級
+func (g *GrantStore) GrantUser(guildID, userID string, level Permission) error {
+	return g.update(guildID, func(gg *GuildGrants) {
+		gg.Users[userID] = level
+	})
+}
+
+// Revoke 移除角色或用戶的授權，回傳是否有移除任何項目
+func (g *GrantStore) Revoke(guildID, targetID string) (bool, error) {
+	removed := false
+	err := g.update(guildID, func(gg *GuildGrants) {
+		if _, ok := gg.Roles[targetID]; ok {
+			delete(gg.Roles, targetID)
+			removed = true
+		}
+		if _, ok := gg.Users[targetID]; ok {
+			delete(gg.Users, targetID)
+			removed = true
+		}
+	})
+	return removed, err
+}
+
+// Get 回傳角色或用戶被授權的等級（沒有授權時 ok 為 false）
+func (g *GrantStore) Get(guildID, targetID string) (level Permission, ok bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	gg, exists := g.guilds[guildID]
+	if !exists {
+		return PermissionNone, false
+	}
+	if level, ok = gg.Roles[targetID]; ok {
+		return level, true
+	}
+	level, ok = gg.Users[targetID]
+	return level, ok
+}
+
+// List 回傳伺服器授權的副本
+func (g *GrantStore) List(guildID string) GuildGrants {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	result := GuildGrants{
+		Roles: make(map[string]Permission),
+		Users: make(map[string]Permission),
+	}
+	if gg, ok := g.guilds[guildID]; ok {
+		for id, level := range gg.Roles {
+			result.Roles[id] = level
+		}
+		for id, level := range gg.Users {
+			result.Users[id] = level
+		}
+	}
+	return result
+}
+
+// Level 計算用戶（含其角色）在伺服器中被授權的最高等級
+func (g *GrantStore) Level(guildID, userID string, roleIDs []string) Permission {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	gg, ok := g.guilds[guildID]
+	if !ok {
+		return PermissionNone
+	}
+
+	level := gg.Users[userID]
+	for _, roleID := range roleIDs {
+		level = max(level, gg.Roles[roleID])
+	}
+	return level
+}
+
+// update 在副本上修改伺服器授權，寫回檔案成功後才替換記憶體中的資料
+func (g *GrantStore) update(guildID string, fn func(*GuildGrants)) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	gg := &GuildGrants{
+		Roles: make(map[string]Permission),
+		Users: make(map[string]Permission),
+	}
+	if old, ok := g.guilds[guildID]; ok {
+		for id, level := range old.Roles {
+			gg.Roles[id] = level
+		}
+		for id, level := range old.Users {
+			gg.Users[id] = level
+		}
+	}
+	fn(gg)
+
+	guilds := make(map[string]*GuildGrants, len(g.guilds)+1)
+	for id, other := range g.guilds {
+		guilds[id] = other
+	}
+	guilds[guildID] = gg
+	if len(gg.Roles) == 0 && len(gg.Users) == 0 {
+		delete(guilds, guildID)
+	}
+
+	if err := g.save(guilds); err != nil {
+		return err
+	}
+	g.guilds = guilds
+	return nil
+}
+
+// save 寫入暫存檔後再 rename，避免寫到一半損毀（需持有鎖）
+func (g *GrantStore) save(guilds map[string]*GuildGrants) error {
+	if g.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(guilds, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(g.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(g.path), err)
+	}
+
+	tmp := g.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", tmp, err)
+	}
+	return os.Rename(tmp, g.path)
+}
+
+// grantedLevel 查詢目前授權資料中的等級
+func grantedLevel(guildID, userID string, roleIDs []string) Permission {
+	return grants.Level(guildID, userID, roleIDs)
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGrantStorePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "permissions.json")
+	store, err := LoadGrants(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.GrantRole("guild", "role", PermissionBotModerator); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.GrantUser("guild", "user", PermissionServerAdmin); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Revoke("guild", "user"); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := LoadGrants(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if level, ok := reloaded.Get("guild", "role"); !ok || level != PermissionBotModerator {
+		t.Errorf("role = %v, %v after reload, want Bot Moderator", level, ok)
+	}
+	if _, ok := reloaded.Get("guild", "user"); ok {
+		t.Errorf("revoked user is still granted after reload")
+	}
+}
+
+func TestGrantStoreKeepsStateWhenSaveFails(t *testing.T) {
+	// The parent "directory" is a file, so every save fails
+	dir := t.TempDir()
+	blocker := filepath.Join(dir, "blocker")
+	if err := os.WriteFile(blocker, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	store := &GrantStore{path: filepath.Join(blocker, "permissions.json"), guilds: map[string]*GuildGrants{
+		"guild": {Roles: map[string]Permission{"role": PermissionServerAdmin}, Users: map[string]Permission{}},
+	}}
+
+	if err := store.GrantRole("guild", "other", PermissionBotModerator); err == nil {
+		t.Fatal("GrantRole succeeded without saving")
+	}
+	if _, ok := store.Get("guild", "other"); ok {
+		t.Errorf("failed grant is visible in memory")
+	}
+
+	if _, err := store.Revoke("guild", "role"); err == nil {
+		t.Fatal("Revoke succeeded without saving")
+	}
+	if level, ok := store.Get("guild", "role"); !ok || level != PermissionServerAdmin {
+		t.Errorf("failed revoke removed the grant (%v, %v)", level, ok)
+	}
+}
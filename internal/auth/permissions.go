@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"fmt"
+
 	"github.com/bwmarrin/discordgo"
 	"discord-bot-template/internal/config"
 )
@@ -11,15 +13,78 @@ type Permission int
 const (
 	PermissionNone Permission = iota
 	PermissionServerAdmin
+	PermissionBotModerator // 只能透過伺服器授權取得（見 grants.go）
 	PermissionBotAdmin
 	PermissionBotOwner
 )
 
+// String 回傳權限等級的顯示名稱
+func (p Permission) String() string {
+	switch p {
+	case PermissionNone:
+		return "None"
+	case PermissionServerAdmin:
+		return "Server Admin"
+	case PermissionBotModerator:
+		return "Bot Moderator"
+	case PermissionBotAdmin:
+		return "Bot Admin"
+	case PermissionBotOwner:
+		return "Bot Owner"
+	}
+	return "Unknown"
+}
+
+// permissionKeys 是權限等級在設定檔中的名稱
+var permissionKeys = map[Permission]string{
+	PermissionNone:         "none",
+	PermissionServerAdmin:  "server_admin",
+	PermissionBotModerator: "bot_moderator",
+	PermissionBotAdmin:     "bot_admin",
+	PermissionBotOwner:     "bot_owner",
+}
+
+// ParsePermission 將名稱（如 "bot_moderator"）轉換為權限等級
+func ParsePermission(name string) (Permission, error) {
+	for p, key := range permissionKeys {
+		if key == name {
+			return p, nil
+		}
+	}
+	return PermissionNone, fmt.Errorf("unknown permission level %q", name)
+}
+
+// MarshalText 以名稱儲存權限等級，避免新增等級時數值改變
+func (p Permission) MarshalText() ([]byte, error) {
+	key, ok := permissionKeys[p]
+	if !ok {
+		return nil, fmt.Errorf("unknown permission level %d", int(p))
+	}
+	return []byte(key), nil
+}
+
+// UnmarshalText 從名稱讀取權限等級
+func (p *Permission) UnmarshalText(text []byte) error {
+	level, err := ParsePermission(string(text))
+	if err != nil {
+		return err
+	}
+	*p = level
+	return nil
+}
+
 var cfg *config.Config
 
 // Init 初始化權限模組（需在 main 中呼叫）
-func Init(c *config.Config) {
+func Init(c *config.Config) error {
 	cfg = c
+
+	store, err := LoadGrants(c.PermissionsFile)
+	if err != nil {
+		return err
+	}
+	grants = store
+	return nil
 }
 
 // isBotOwner 檢查用戶是否為 Bot 擁有者
@@ -50,16 +115,41 @@ func isBotAdmin(userID string) bool {
 
 // isServerAdmin 檢查用戶是否為伺服器管理員（Discord Administrator 權限）
 func isServerAdmin(s *discordgo.Session, guildID, userID string) bool {
-	member, err := s.GuildMember(guildID, userID)
+	if guildID == "" {
+		return false
+	}
+
+	member, err := getMember(s, guildID, userID)
 	if err != nil {
 		return false
 	}
 
-	guild, err := s.Guild(guildID)
+	guild, err := getGuild(s, guildID)
 	if err != nil {
 		return false
 	}
 
+	return hasAdministrator(guild, member, userID)
+}
+
+// getMember 優先從 state cache 取得成員，必要時才呼叫 REST
+func getMember(s *discordgo.Session, guildID, userID string) (*discordgo.Member, error) {
+	if member, err := s.State.Member(guildID, userID); err == nil {
+		return member, nil
+	}
+	return s.GuildMember(guildID, userID)
+}
+
+// getGuild 優先從 state cache 取得伺服器，必要時才呼叫 REST
+func getGuild(s *discordgo.Session, guildID string) (*discordgo.Guild, error) {
+	if guild, err := s.State.Guild(guildID); err == nil {
+		return guild, nil
+	}
+	return s.Guild(guildID)
+}
+
+// hasAdministrator 檢查成員是否為伺服器擁有者或有 Administrator 角色
+func hasAdministrator(guild *discordgo.Guild, member *discordgo.Member, userID string) bool {
 	// 伺服器擁有者自動擁有管理員權限
 	if guild.OwnerID == userID {
 		return true
@@ -84,13 +174,92 @@ func CheckPermission(s *discordgo.Session, guildID, userID string) Permission {
 	if isBotAdmin(userID) {
 		return PermissionBotAdmin
 	}
+
+	level := PermissionNone
 	if isServerAdmin(s, guildID, userID) {
-		return PermissionServerAdmin
+		level = PermissionServerAdmin
+	}
+	if guildID != "" {
+		var roles []string
+		if member, err := getMember(s, guildID, userID); err == nil {
+			roles = member.Roles
+		}
+		level = max(level, grantedLevel(guildID, userID, roles))
 	}
-	return PermissionNone
+	return level
 }
 
 // HasPermission 檢查用戶是否有指定的權限等級（或更高）
 func HasPermission(s *discordgo.Session, guildID, userID string, required Permission) bool {
 	return CheckPermission(s, guildID, userID) >= required
 }
+
+// ============================================
+// Interaction-based 檢查（優先使用 payload，不打 REST）
+// ============================================
+
+// CheckInteractionPermission 從 interaction 計算用戶的最高權限等級
+//
+// 優先順序：
+//  1. i.Member.Permissions（Discord 已算好的權限，含擁有者）
+//  2. State cache 的伺服器角色
+//  3. REST（最後手段）
+//
+// 私訊（GuildID 為空）只會有 Bot Owner / Bot Admin 等級。
+func CheckInteractionPermission(s *discordgo.Session, i *discordgo.InteractionCreate) Permission {
+	userID := InteractionUserID(i)
+	if isBotOwner(userID) {
+		return PermissionBotOwner
+	}
+	if isBotAdmin(userID) {
+		return PermissionBotAdmin
+	}
+	if i.GuildID == "" || i.Member == nil {
+		return PermissionNone
+	}
+
+	level := PermissionNone
+	if isInteractionServerAdmin(s, i, userID) {
+		level = PermissionServerAdmin
+	}
+	return max(level, grantedLevel(i.GuildID, userID, i.Member.Roles))
+}
+
+// HasInteractionPermission 檢查 interaction 的用戶是否有指定的權限等級（或更高）
+func HasInteractionPermission(s *discordgo.Session, i *discordgo.InteractionCreate, required Permission) bool {
+	return CheckInteractionPermission(s, i) >= required
+}
+
+// IsGuildAdmin 檢查 interaction 的用戶本身是否為伺服器管理員（擁有者或 Administrator），不計入伺服器授權
+func IsGuildAdmin(s *discordgo.Session, i *discordgo.InteractionCreate) bool {
+	if i.GuildID == "" || i.Member == nil {
+		return false
+	}
+	return isInteractionServerAdmin(s, i, InteractionUserID(i))
+}
+
+// isInteractionServerAdmin 用 interaction 內的成員資料判斷是否為伺服器管理員
+func isInteractionServerAdmin(s *discordgo.Session, i *discordgo.InteractionCreate, userID string) bool {
+	// Discord 在 interaction 中提供已計算好的權限
+	if i.Member.Permissions != 0 {
+		return i.Member.Permissions&discordgo.PermissionAdministrator != 0
+	}
+
+	// 沒有權限欄位時，用 state cache 的角色計算
+	if guild, err := s.State.Guild(i.GuildID); err == nil {
+		return hasAdministrator(guild, i.Member, userID)
+	}
+
+	return isServerAdmin(s, i.GuildID, userID)
+}
+
+// InteractionUserID 取得觸發 interaction 的用戶 ID（伺服器或私訊）
+func InteractionUserID(i *discordgo.InteractionCreate) string {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User.ID
+	}
+	if i.User != nil {
+		return i.User.ID
+	}
+	return ""
+}
@@ -1,13 +1,17 @@
 package bot
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"runtime/debug"
 	"syscall"
 
+	"discord-bot-template/internal/auth"
 	"discord-bot-template/internal/commands"
+	"discord-bot-template/internal/component"
 	"discord-bot-template/internal/config"
 
 	"github.com/bwmarrin/discordgo"
@@ -15,11 +19,18 @@ import (
 
 // Bot represents the Discord bot instance
 type Bot struct {
-	session           *discordgo.Session
-	config            *config.Config
-	handlers          map[string]commands.Handler
-	componentHandlers map[string]commands.Handler
-	modalHandlers     map[string]commands.Handler
+	session         *discordgo.Session
+	config          *config.Config
+	handlers        map[string]*commands.Route
+	userCommands    map[string]*commands.Route
+	messageCommands map[string]*commands.Route
+	componentRouter *commands.Router
+	modalRouter     *commands.Router
+	autocomplete    map[string]commands.AutocompleteHandler
+	middlewares     []commands.Middleware
+
+	ctx    context.Context // Parent of every handler context, cancelled by Stop
+	cancel context.CancelFunc
 }
 
 // New creates a new bot instance
@@ -30,12 +41,19 @@ func New(cfg *config.Config) (*Bot, error) {
 		return nil, fmt.Errorf("failed to create Discord session: %w", err)
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+
 	bot := &Bot{
-		session:           session,
-		config:            cfg,
-		handlers:          commands.GetHandlers(),
-		componentHandlers: commands.GetComponentHandlers(),
-		modalHandlers:     commands.GetModalHandlers(),
+		session:         session,
+		config:          cfg,
+		handlers:        commands.GetHandlers(),
+		userCommands:    commands.GetUserCommandHandlers(),
+		messageCommands: commands.GetMessageCommandHandlers(),
+		componentRouter: commands.GetComponentRouter(),
+		modalRouter:     commands.GetModalRouter(),
+		autocomplete:    commands.GetAutocompleteHandlers(),
+		ctx:             ctx,
+		cancel:          cancel,
 	}
 
 	// Register event handlers
@@ -44,6 +62,13 @@ func New(cfg *config.Config) (*Bot, error) {
 	return bot, nil
 }
 
+// Use adds global middlewares, applied to every interaction: commands, components and modals
+// (including those answered by collectors and dynamic listeners) and autocomplete.
+// Global middlewares run before per-route middlewares, in the order they were added.
+func (b *Bot) Use(middlewares ...commands.Middleware) {
+	b.middlewares = append(b.middlewares, middlewares...)
+}
+
 // registerHandlers registers all event handlers
 func (b *Bot) registerHandlers() {
 	// Ready event
@@ -69,34 +94,246 @@ func (b *Bot) onReady(s *discordgo.Session, r *discordgo.Ready) {
 func (b *Bot) onInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	switch i.Type {
 	case discordgo.InteractionApplicationCommand:
+		data := i.ApplicationCommandData()
+
+		// Context menus (user / message)
+		switch data.CommandType {
+		case discordgo.UserApplicationCommand:
+			b.dispatchContextMenu(s, i, b.userCommands, "user")
+			return
+		case discordgo.MessageApplicationCommand:
+			b.dispatchContextMenu(s, i, b.messageCommands, "message")
+			return
+		}
+
 		// Slash commands
-		commandName := i.ApplicationCommandData().Name
-		if handler, ok := b.handlers[commandName]; ok {
-			handler(s, i)
+		// Subcommands are matched by their full path ("ticket admin close")
+		commandPath := commands.CommandPath(data)
+		if route, ok := b.handlers[commandPath]; ok {
+			b.dispatch(route, s, i)
 		} else {
-			log.Printf("Unknown command: %s", commandName)
+			log.Printf("Unknown command: /%s", commandPath)
 		}
 
+	case discordgo.InteractionApplicationCommandAutocomplete:
+		// Option autocomplete
+		b.onAutocomplete(s, i)
+
 	case discordgo.InteractionMessageComponent:
+		// Handlers waiting with ctx.AwaitComponent, then dynamic components (paginators, ...),
+		// take precedence over registered ones
+		if b.dispatchDynamic(s, i) {
+			return
+		}
+
 		// Buttons, Select Menus
 		customID := i.MessageComponentData().CustomID
-		if handler, ok := b.componentHandlers[customID]; ok {
-			handler(s, i)
+		if route, ok := b.componentRouter.Match(customID); ok {
+			b.dispatch(route, s, i)
 		} else {
 			log.Printf("Unknown component: %s", customID)
 		}
 
 	case discordgo.InteractionModalSubmit:
-		// Modal submissions
 		customID := i.ModalSubmitData().CustomID
-		if handler, ok := b.modalHandlers[customID]; ok {
-			handler(s, i)
+		route, ok := b.modalRouter.Match(customID)
+
+		// Submissions failing the modal's validators are answered here with a "Try again" button
+		var rules map[string]component.InputRule
+		if ok {
+			rules = route.Validators
+		}
+		if commands.RejectInvalidModal(s, i, rules) || b.dispatchDynamic(s, i) {
+			return
+		}
+
+		// Modal submissions
+		if ok {
+			b.dispatch(route, s, i)
 		} else {
 			log.Printf("Unknown modal: %s", customID)
 		}
 	}
 }
 
+// dispatch runs a route's handler wrapped in global then per-route middlewares.
+// Panics and returned errors are logged and reported to the user as an ephemeral error embed.
+func (b *Bot) dispatch(route *commands.Route, s *discordgo.Session, i *discordgo.InteractionCreate) {
+	// Cancelled when the handler returns or the bot shuts down
+	ctx, cancel := commands.NewContext(b.ctx, s, i)
+	defer cancel()
+
+	// Defer automatically if the handler hasn't responded in time (Discord allows 3 seconds)
+	if b.config.AutoDeferAfter > 0 {
+		ctx.StartAutoDefer(b.config.AutoDeferAfter)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Panic in %s: %v\n%s", interactionContext(i), r, debug.Stack())
+			b.replyError(ctx, fmt.Errorf("panic: %v", r))
+		}
+	}()
+
+	// Permission and cooldown checks run before any middleware so rejected requests have no side effects
+	if !b.allow(route, ctx) {
+		return
+	}
+
+	middlewares := make([]commands.Middleware, 0, len(b.middlewares)+len(route.Middlewares))
+	middlewares = append(middlewares, b.middlewares...)
+	middlewares = append(middlewares, route.Middlewares...)
+
+	if err := commands.Chain(route.Handler, middlewares...)(ctx); err != nil {
+		log.Printf("Error in %s: %v", interactionContext(i), err)
+		b.replyError(ctx, err)
+	}
+}
+
+// dispatchDynamic hands a component or modal to a waiting collector or a dynamic listener
+// (paginators, ...), wrapped in the global middlewares. It reports false if neither wants it.
+func (b *Bot) dispatchDynamic(s *discordgo.Session, i *discordgo.InteractionCreate) (handled bool) {
+	if !commands.Collecting(i) && !listening(i) {
+		return false
+	}
+
+	ctx, cancel := commands.NewContext(b.ctx, s, i)
+	defer cancel()
+
+	reached := false
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Panic in listener %s: %v\n%s", interactionContext(i), r, debug.Stack())
+			b.replyError(ctx, fmt.Errorf("panic: %v", r))
+			handled = true
+		}
+	}()
+
+	err := commands.Chain(func(ctx *commands.Context) error {
+		reached = true
+		if commands.DeliverCollected(s, i) {
+			handled = true
+			return nil
+		}
+		var err error
+		handled, err = component.Dispatch(ctx.Response, i)
+		return err
+	}, b.middlewares...)(ctx)
+	if err != nil {
+		log.Printf("Error in %s: %v", interactionContext(i), err)
+		b.replyError(ctx, err)
+	}
+
+	// A middleware that stopped the chain has answered the interaction. Otherwise the collector
+	// or listener went away in the meantime, so let the registered handlers have it.
+	return handled || !reached
+}
+
+// listening reports whether a dynamic listener handles the component or modal
+func listening(i *discordgo.InteractionCreate) bool {
+	switch i.Type {
+	case discordgo.InteractionMessageComponent:
+		return component.Listening(i.MessageComponentData().CustomID)
+	case discordgo.InteractionModalSubmit:
+		return component.Listening(i.ModalSubmitData().CustomID)
+	}
+	return false
+}
+
+// allow enforces the route's permission level and cooldown, replying to the user when rejected
+func (b *Bot) allow(route *commands.Route, ctx *commands.Context) bool {
+	if route.Permission == auth.PermissionNone && route.Cooldown == nil {
+		return true
+	}
+
+	level := ctx.Permission()
+
+	var rejection *discordgo.MessageEmbed
+	if level < route.Permission {
+		rejection = commands.PermissionDeniedEmbed(ctx.Translator(), route.Permission)
+	} else if route.Cooldown != nil && level < auth.PermissionBotAdmin {
+		if retryAt, ok := route.Cooldown.Take(ctx.Interaction); !ok {
+			rejection = commands.CooldownEmbed(ctx.Translator(), retryAt)
+		}
+	}
+
+	if rejection == nil {
+		return true
+	}
+	if err := commands.ReplyError(ctx.Response, rejection); err != nil {
+		log.Printf("Failed to send rejection for %s: %v", interactionContext(ctx.Interaction), err)
+	}
+	return false
+}
+
+// replyError shows a handler error to the user
+func (b *Bot) replyError(ctx *commands.Context, err error) {
+	if err := commands.ReplyError(ctx.Response, commands.ErrorEmbed(ctx.Translator(), err)); err != nil {
+		log.Printf("Failed to send error reply for %s: %v", interactionContext(ctx.Interaction), err)
+	}
+}
+
+// interactionContext describes an interaction for logs (command, user, guild)
+func interactionContext(i *discordgo.InteractionCreate) string {
+	guild := i.GuildID
+	if guild == "" {
+		guild = "DM"
+	}
+	return fmt.Sprintf("%s (user %s, guild %s)", commands.DescribeInteraction(i), auth.InteractionUserID(i), guild)
+}
+
+// dispatchContextMenu runs a user or message context menu command
+func (b *Bot) dispatchContextMenu(s *discordgo.Session, i *discordgo.InteractionCreate, routes map[string]*commands.Route, kind string) {
+	name := i.ApplicationCommandData().Name
+	if route, ok := routes[name]; ok {
+		b.dispatch(route, s, i)
+	} else {
+		log.Printf("Unknown %s command: %s", kind, name)
+	}
+}
+
+// onAutocomplete responds with choices from the focused option's provider
+func (b *Bot) onAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Panic in autocomplete %s: %v\n%s", interactionContext(i), r, debug.Stack())
+		}
+	}()
+
+	key, focused := commands.ResolveAutocomplete(i.ApplicationCommandData())
+
+	ctx, cancel := commands.NewContext(b.ctx, s, i)
+	defer cancel()
+
+	// Global middlewares run here too; one that stops the chain leaves the choices empty
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	err := commands.Chain(func(ctx *commands.Context) error {
+		if handler, ok := b.autocomplete[key]; ok {
+			choices = handler(ctx, focused)
+		} else {
+			log.Printf("Unknown autocomplete: %s", key)
+		}
+		return nil
+	}, b.middlewares...)(ctx)
+	if err != nil {
+		log.Printf("Error in autocomplete %s: %v", interactionContext(i), err)
+	}
+
+	if len(choices) > commands.MaxAutocompleteChoices {
+		choices = choices[:commands.MaxAutocompleteChoices]
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to respond to autocomplete %s: %v", key, err)
+	}
+}
+
 // Start starts the bot
 func (b *Bot) Start() error {
 	// Set intents
@@ -141,7 +378,14 @@ func (b *Bot) registerCommands() error {
 	}
 
 	for _, cmd := range registered {
-		log.Printf("Registered command: /%s", cmd.Name)
+		switch cmd.Type {
+		case discordgo.UserApplicationCommand:
+			log.Printf("Registered user command: %s", cmd.Name)
+		case discordgo.MessageApplicationCommand:
+			log.Printf("Registered message command: %s", cmd.Name)
+		default:
+			log.Printf("Registered command: /%s", cmd.Name)
+		}
 	}
 
 	return nil
@@ -158,6 +402,9 @@ func (b *Bot) Wait() {
 func (b *Bot) Stop() error {
 	log.Println("Shutting down...")
 
+	// Cancel running handlers
+	b.cancel()
+
 	// Optionally remove commands on shutdown (uncomment if desired)
 	// b.removeCommands()
 
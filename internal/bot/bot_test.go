@@ -0,0 +1,159 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"discord-bot-template/internal/commands"
+	"discord-bot-template/internal/component"
+	"discord-bot-template/internal/config"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// newTestBot returns a bot with the given global middlewares whose API calls all succeed offline
+func newTestBot(t *testing.T, middlewares ...commands.Middleware) *Bot {
+	t.Helper()
+	s, err := discordgo.New("Bot test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Client = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader("{}")),
+			Request:    r,
+		}, nil
+	})}
+
+	return &Bot{
+		session:         s,
+		config:          &config.Config{},
+		componentRouter: commands.NewRouter(),
+		modalRouter:     commands.NewRouter(),
+		autocomplete:    make(map[string]commands.AutocompleteHandler),
+		middlewares:     middlewares,
+		ctx:             context.Background(),
+	}
+}
+
+// recordMiddleware records the interactions it sees and stops the chain when block is set
+func recordMiddleware(seen *[]string, block bool) commands.Middleware {
+	return func(next commands.Handler) commands.Handler {
+		return func(ctx *commands.Context) error {
+			*seen = append(*seen, commands.DescribeInteraction(ctx.Interaction))
+			if block {
+				return errors.New("blocked")
+			}
+			return next(ctx)
+		}
+	}
+}
+
+func componentClick(customID string) *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		ID:        "click",
+		AppID:     "app",
+		Token:     "token",
+		Type:      discordgo.InteractionMessageComponent,
+		ChannelID: "channel",
+		User:      &discordgo.User{ID: "user"},
+		Data:      discordgo.MessageComponentInteractionData{CustomID: customID},
+	}}
+}
+
+func TestGlobalMiddlewaresRunForListeners(t *testing.T) {
+	for _, block := range []bool{false, true} {
+		var seen []string
+		b := newTestBot(t, recordMiddleware(&seen, block))
+
+		key := component.NewListenerKey("test")
+		called := false
+		component.Listen(key, func(r component.ListenerResponder, i *discordgo.InteractionCreate, action string) error {
+			called = true
+			return nil
+		})
+		t.Cleanup(func() { component.Unlisten(key) })
+
+		b.onInteraction(b.session, componentClick(component.ListenerID(key, "next")))
+
+		if len(seen) != 1 {
+			t.Errorf("block=%v: middleware saw %v, want the click once", block, seen)
+		}
+		if called == block {
+			t.Errorf("block=%v: listener called = %v", block, called)
+		}
+	}
+}
+
+func TestGlobalMiddlewaresRunForCollectors(t *testing.T) {
+	var seen []string
+	b := newTestBot(t, recordMiddleware(&seen, false))
+
+	i := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		ID: "command", Type: discordgo.InteractionApplicationCommand, ChannelID: "channel",
+		User: &discordgo.User{ID: "user"},
+	}}
+	ctx, cancel := commands.NewContext(context.Background(), b.session, i)
+	defer cancel()
+
+	collected := make(chan error, 1)
+	go func() {
+		_, err := ctx.AwaitComponent(commands.CollectCustomID("collect_me"), commands.CollectTimeout(time.Second))
+		collected <- err
+	}()
+
+	click := componentClick("collect_me")
+	for deadline := time.Now().Add(time.Second); !commands.Collecting(click); {
+		if time.Now().After(deadline) {
+			t.Fatal("collector was never added")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	b.onInteraction(b.session, click)
+	if err := <-collected; err != nil {
+		t.Fatalf("AwaitComponent = %v", err)
+	}
+	if len(seen) != 1 || seen[0] != "component collect_me" {
+		t.Errorf("middleware saw %v, want the collected click", seen)
+	}
+}
+
+func TestGlobalMiddlewaresRunForAutocomplete(t *testing.T) {
+	var seen []string
+	b := newTestBot(t, recordMiddleware(&seen, false))
+
+	called := false
+	b.autocomplete["search/query"] = func(ctx *commands.Context, focused *discordgo.ApplicationCommandInteractionDataOption) []*discordgo.ApplicationCommandOptionChoice {
+		called = true
+		return nil
+	}
+
+	b.onInteraction(b.session, &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		ID: "autocomplete", AppID: "app", Token: "token",
+		Type: discordgo.InteractionApplicationCommandAutocomplete,
+		Data: discordgo.ApplicationCommandInteractionData{
+			Name: "search",
+			Options: []*discordgo.ApplicationCommandInteractionDataOption{
+				{Name: "query", Type: discordgo.ApplicationCommandOptionString, Value: "go", Focused: true},
+			},
+		},
+	}})
+
+	if len(seen) != 1 || !called {
+		t.Errorf("middleware saw %v, provider called = %v", seen, called)
+	}
+}
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"github.com/bwmarrin/discordgo"
+)
+
+// MaxAutocompleteChoices is the maximum number of choices Discord accepts
+const MaxAutocompleteChoices = 25
+
+// AutocompleteHandler returns choices for the focused option.
+// focused.StringValue() (or focused.Value) is what the user has typed so far.
+// Other options typed so far are available through ctx.Option / ctx.StringOption.
+type AutocompleteHandler func(ctx *Context, focused *discordgo.ApplicationCommandInteractionDataOption) []*discordgo.ApplicationCommandOptionChoice
+
+// ============================================
+// Auto-registration (使用 init() 自動註冊)
+// ============================================
+
+var autocompleteHandlers = make(map[string]AutocompleteHandler)
+
+// RegisterAutocomplete registers an autocomplete provider for an option (call in init())
+// commandPath is the same path used for dispatch ("search" or "ticket open").
+// The option is marked as autocomplete in the generated definitions.
+func RegisterAutocomplete(commandPath, option string, handler AutocompleteHandler) {
+	autocompleteHandlers[autocompleteKey(commandPath, option)] = handler
+}
+
+// GetAutocompleteHandlers returns all autocomplete providers keyed by "path/option"
+func GetAutocompleteHandlers() map[string]AutocompleteHandler {
+	return autocompleteHandlers
+}
+
+// ResolveAutocomplete returns the provider key and focused option of an autocomplete interaction
+func ResolveAutocomplete(data discordgo.ApplicationCommandInteractionData) (string, *discordgo.ApplicationCommandInteractionDataOption) {
+	focused := FocusedOption(SubcommandOptions(data))
+	if focused == nil {
+		return "", nil
+	}
+	return autocompleteKey(CommandPath(data), focused.Name), focused
+}
+
+func autocompleteKey(commandPath, option string) string {
+	return commandPath + "/" + option
+}
+
+// FocusedOption returns the option the user is currently typing in
+func FocusedOption(options []*discordgo.ApplicationCommandInteractionDataOption) *discordgo.ApplicationCommandInteractionDataOption {
+	for _, opt := range options {
+		if opt.Focused {
+			return opt
+		}
+	}
+	return nil
+}
+
+// markAutocomplete flags options that have a registered provider
+func markAutocomplete(definition *discordgo.ApplicationCommand) {
+	markOptions(definition.Name, definition.Options)
+}
+
+func markOptions(path string, options []*discordgo.ApplicationCommandOption) {
+	for _, opt := range options {
+		switch opt.Type {
+		case discordgo.ApplicationCommandOptionSubCommandGroup,
+			discordgo.ApplicationCommandOptionSubCommand:
+			markOptions(path+" "+opt.Name, opt.Options)
+		default:
+			if _, ok := autocompleteHandlers[autocompleteKey(path, opt.Name)]; ok {
+				opt.Autocomplete = true
+			}
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"discord-bot-template/internal/auth"
+	"discord-bot-template/internal/component"
+	"discord-bot-template/internal/embed"
+	"discord-bot-template/internal/i18n"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ============================================
+// Collectors (在 handler 中等待下一個互動)
+// ============================================
+//
+//	ctx.Reply(&discordgo.InteractionResponseData{
+//	    Content:    "Are you sure?",
+//	    Components: []discordgo.MessageComponent{component.YesNoRow(ctx.UniqueID("yes"), ctx.UniqueID("no"))},
+//	})
+//	click, err := ctx.AwaitComponent(CollectCustomID(ctx.UniqueID("yes"), ctx.UniqueID("no")))
+//	if errors.Is(err, ErrCollectTimeout) { ... }
+//	click.Update(...)
+
+const (
+	// DefaultComponentTimeout is how long AwaitComponent waits by default
+	DefaultComponentTimeout = 2 * time.Minute
+	// DefaultModalTimeout is how long AwaitModal waits by default (typing takes a while)
+	DefaultModalTimeout = 10 * time.Minute
+)
+
+// ErrCollectTimeout is returned when no matching interaction arrived in time
+var ErrCollectTimeout = errors.New("timed out waiting for interaction")
+
+// CollectOption configures what a collector accepts
+type CollectOption func(*collector)
+
+// CollectCustomID accepts only these custom IDs (default: any component the user clicks in
+// the handler's channel, except those with a registered handler or a dynamic listener such
+// as a paginator)
+func CollectCustomID(customIDs ...string) CollectOption {
+	return func(c *collector) {
+		for _, id := range customIDs {
+			c.customIDs[id] = true
+		}
+	}
+}
+
+// CollectUser accepts only this user (default: the user who invoked the handler)
+func CollectUser(userID string) CollectOption {
+	return func(c *collector) {
+		c.userID = userID
+	}
+}
+
+// CollectAnyUser accepts interactions from anyone
+func CollectAnyUser() CollectOption {
+	return func(c *collector) {
+		c.userID = ""
+	}
+}
+
+// CollectAnyChannel accepts components from any channel when no custom IDs are given
+func CollectAnyChannel() CollectOption {
+	return func(c *collector) {
+		c.channelID = ""
+	}
+}
+
+// CollectTimeout sets how long to wait
+func CollectTimeout(timeout time.Duration) CollectOption {
+	return func(c *collector) {
+		c.timeout = timeout
+	}
+}
+
+// CollectFilter adds a custom check; interactions it rejects are left to other handlers.
+// Several filters must all accept the interaction.
+func CollectFilter(filter func(i *discordgo.InteractionCreate) bool) CollectOption {
+	return func(c *collector) {
+		c.filters = append(c.filters, filter)
+	}
+}
+
+type collector struct {
+	kind      discordgo.InteractionType
+	customIDs map[string]bool
+	userID    string
+	channelID string // Only used without custom IDs
+	timeout   time.Duration
+	filters   []func(i *discordgo.InteractionCreate) bool
+	ch        chan collected
+}
+
+type collected struct {
+	session     *discordgo.Session
+	interaction *discordgo.InteractionCreate
+}
+
+var collectors = struct {
+	sync.Mutex
+	waiting []*collector
+}{}
+
+// UniqueID returns a custom ID that is unique to this interaction ("confirm" → "confirm:<interaction id>"),
+// so concurrent uses of the same command don't receive each other's clicks
+func (c *Context) UniqueID(name string) string {
+	return name + ":" + c.Interaction.ID
+}
+
+// AwaitComponent waits for the next button / select interaction matching the options.
+// The returned Context answers the collected interaction (Update, Reply, ...).
+func (c *Context) AwaitComponent(opts ...CollectOption) (*Context, error) {
+	return c.await(discordgo.InteractionMessageComponent, DefaultComponentTimeout, opts)
+}
+
+// AwaitModal opens the modal and waits for it to be submitted.
+// This must be the first response to the interaction.
+func (c *Context) AwaitModal(modal *discordgo.InteractionResponse, opts ...CollectOption) (*Context, error) {
+	if err := c.Respond(modal); err != nil {
+		return nil, err
+	}
+	opts = append([]CollectOption{CollectCustomID(modal.Data.CustomID)}, opts...)
+	return c.await(discordgo.InteractionModalSubmit, DefaultModalTimeout, opts)
+}
+
+func (c *Context) await(kind discordgo.InteractionType, timeout time.Duration, opts []CollectOption) (*Context, error) {
+	col := &collector{
+		kind:      kind,
+		customIDs: make(map[string]bool),
+		timeout:   timeout,
+		ch:        make(chan collected, 1),
+	}
+	if user := c.User(); user != nil {
+		col.userID = user.ID
+	}
+	col.channelID = c.ChannelID()
+	for _, opt := range opts {
+		opt(col)
+	}
+
+	addCollector(col)
+
+	timer := time.NewTimer(col.timeout)
+	defer timer.Stop()
+
+	select {
+	case got := <-col.ch:
+		return c.child(got.session, got.interaction), nil
+	case <-timer.C:
+		if !removeCollector(col) {
+			// Delivered while the timer fired: the interaction is already ours to answer
+			got := <-col.ch
+			return c.child(got.session, got.interaction), nil
+		}
+		return nil, ErrCollectTimeout
+	case <-c.Done():
+		if !removeCollector(col) {
+			// Delivered while the handler was giving up: don't leave it unanswered
+			got := <-col.ch
+			t := i18n.ForInteraction(got.interaction)
+			e := embed.Error(t.T("errors.expired.title"), t.T("errors.interaction_ended"))
+			if err := ReplyError(NewResponse(got.session, got.interaction), e); err != nil {
+				log.Printf("Failed to reject %s: %v", DescribeInteraction(got.interaction), err)
+			}
+		}
+		return nil, c.Err()
+	}
+}
+
+// child creates the Context for a collected interaction.
+// It lives until the waiting handler returns and inherits its auto-defer setting.
+func (c *Context) child(s *discordgo.Session, i *discordgo.InteractionCreate) *Context {
+	ctx, cancel := NewContext(c.Context, s, i)
+	context.AfterFunc(ctx, cancel)
+
+	if c.autoDeferAfter > 0 {
+		ctx.StartAutoDefer(c.autoDeferAfter)
+	}
+	return ctx
+}
+
+// accepts runs the collector's filters
+func (col *collector) accepts(i *discordgo.InteractionCreate) bool {
+	for _, filter := range col.filters {
+		if !filter(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// routed reports whether a custom ID has a registered handler or a dynamic listener
+func routed(kind discordgo.InteractionType, customID string) bool {
+	router := componentRouter
+	if kind == discordgo.InteractionModalSubmit {
+		router = modalRouter
+	}
+	if _, ok := router.Match(customID); ok {
+		return true
+	}
+	return component.Listening(customID)
+}
+
+func addCollector(col *collector) {
+	collectors.Lock()
+	defer collectors.Unlock()
+	collectors.waiting = append(collectors.waiting, col)
+}
+
+// removeCollector stops a collector, reporting false if it already received an interaction
+func removeCollector(col *collector) bool {
+	collectors.Lock()
+	defer collectors.Unlock()
+	for idx, c := range collectors.waiting {
+		if c == col {
+			collectors.waiting = append(collectors.waiting[:idx], collectors.waiting[idx+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Collecting reports whether DeliverCollected would take the interaction
+// (a waiting collector wants it, or it must be rejected for another user)
+func Collecting(i *discordgo.InteractionCreate) bool {
+	customID, ok := collectableID(i)
+	if !ok {
+		return false
+	}
+
+	collectors.Lock()
+	defer collectors.Unlock()
+	idx, rejected := matchCollector(i, customID)
+	return idx >= 0 || rejected
+}
+
+// DeliverCollected hands a component / modal interaction to a waiting collector.
+// It returns false if no collector wants it, so the caller can use the registered handlers.
+// Clicks from other users on a collector's custom ID are rejected with an ephemeral message.
+func DeliverCollected(s *discordgo.Session, i *discordgo.InteractionCreate) bool {
+	customID, ok := collectableID(i)
+	if !ok {
+		return false
+	}
+
+	collectors.Lock()
+	var target *collector
+	idx, rejected := matchCollector(i, customID)
+	if idx >= 0 {
+		// Each collector receives one interaction
+		target = collectors.waiting[idx]
+		collectors.waiting = append(collectors.waiting[:idx], collectors.waiting[idx+1:]...)
+	}
+	collectors.Unlock()
+
+	if target != nil {
+		target.ch <- collected{session: s, interaction: i}
+		return true
+	}
+	if rejected {
+		t := i18n.ForInteraction(i)
+		e := embed.Error(t.T("errors.not_for_you.title"), t.T("errors.not_for_you.message"))
+		if err := ReplyError(NewResponse(s, i), e); err != nil {
+			log.Printf("Failed to reject %s: %v", DescribeInteraction(i), err)
+		}
+		return true
+	}
+	return false
+}
+
+// collectableID returns the custom ID of a component or modal interaction
+func collectableID(i *discordgo.InteractionCreate) (string, bool) {
+	switch i.Type {
+	case discordgo.InteractionMessageComponent:
+		return i.MessageComponentData().CustomID, true
+	case discordgo.InteractionModalSubmit:
+		return i.ModalSubmitData().CustomID, true
+	}
+	return "", false
+}
+
+// matchCollector returns the index of the first collector that wants the interaction (-1 if none),
+// and whether another user clicked a collector's custom ID (caller holds the lock)
+func matchCollector(i *discordgo.InteractionCreate, customID string) (idx int, rejected bool) {
+	userID := auth.InteractionUserID(i)
+	for n, col := range collectors.waiting {
+		if col.kind != i.Type || (len(col.customIDs) > 0 && !col.customIDs[customID]) {
+			continue
+		}
+		// Without custom IDs, stay in the handler's channel and leave routed components alone
+		if len(col.customIDs) == 0 && ((col.channelID != "" && col.channelID != i.ChannelID) || routed(i.Type, customID)) {
+			continue
+		}
+		if !col.accepts(i) {
+			continue
+		}
+		if col.userID != "" && col.userID != userID {
+			rejected = rejected || len(col.customIDs) > 0
+			continue
+		}
+		return n, rejected
+	}
+	return -1, rejected
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// click creates a button interaction from user "user" in channel "channel"
+func click(customID string) *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		ID:        "click_" + customID,
+		Type:      discordgo.InteractionMessageComponent,
+		ChannelID: "channel",
+		User:      &discordgo.User{ID: "user"},
+		Data:      discordgo.MessageComponentInteractionData{CustomID: customID},
+	}}
+}
+
+// waiting adds a component collector for "user" in "channel" and removes it when the test ends
+func waiting(t *testing.T, opts ...CollectOption) *collector {
+	t.Helper()
+	col := &collector{
+		kind:      discordgo.InteractionMessageComponent,
+		customIDs: make(map[string]bool),
+		userID:    "user",
+		channelID: "channel",
+		ch:        make(chan collected, 1),
+	}
+	for _, opt := range opts {
+		opt(col)
+	}
+	addCollector(col)
+	t.Cleanup(func() { removeCollector(col) })
+	return col
+}
+
+func TestDeliverCollectedSkipsRoutedComponents(t *testing.T) {
+	RegisterComponent("collector_test_close:{id}", func(ctx *Context) error { return nil })
+	s := newTestSession(t)
+	col := waiting(t)
+
+	if DeliverCollected(s, click("collector_test_close:1")) {
+		t.Fatalf("collector took a click on a registered component")
+	}
+	if !DeliverCollected(s, click("collector_test_free")) {
+		t.Fatalf("collector didn't receive an unrouted click")
+	}
+	if got := (<-col.ch).interaction.MessageComponentData().CustomID; got != "collector_test_free" {
+		t.Errorf("collected %q", got)
+	}
+}
+
+func TestDeliverCollectedOtherChannel(t *testing.T) {
+	s := newTestSession(t)
+	waiting(t)
+
+	i := click("collector_test_free")
+	i.ChannelID = "other"
+	if DeliverCollected(s, i) {
+		t.Errorf("collector took a click from another channel")
+	}
+}
+
+func TestCollectFilterCombines(t *testing.T) {
+	s := newTestSession(t)
+	waiting(t,
+		CollectFilter(func(i *discordgo.InteractionCreate) bool { return i.ChannelID == "channel" }),
+		CollectFilter(func(i *discordgo.InteractionCreate) bool { return i.MessageComponentData().CustomID == "b" }),
+	)
+
+	if DeliverCollected(s, click("a")) {
+		t.Errorf("collector took a click its second filter rejects")
+	}
+	if !DeliverCollected(s, click("b")) {
+		t.Errorf("collector didn't receive a click both filters accept")
+	}
+}
@@ -1,16 +1,22 @@
 package commands
 
 import (
+	"strings"
+
+	"discord-bot-template/internal/auth"
+	"discord-bot-template/internal/i18n"
+
 	"github.com/bwmarrin/discordgo"
 )
 
-// Handler is a function that handles an interaction
-type Handler func(s *discordgo.Session, i *discordgo.InteractionCreate)
+// Handler is a function that handles an interaction.
+// A returned error is logged and shown to the user as an ephemeral error embed (see UserError).
+type Handler func(ctx *Context) error
 
 // Command represents a slash command with its definition and handler
 type Command struct {
 	Definition *discordgo.ApplicationCommand
-	Handler    Handler
+	*Route
 }
 
 // ============================================
@@ -18,55 +24,86 @@ type Command struct {
 // ============================================
 
 var registeredCommands []*Command
-var componentHandlers = make(map[string]Handler)
-var modalHandlers = make(map[string]Handler)
+var componentRouter = NewRouter()
+var modalRouter = NewRouter()
 
 // RegisterCommand registers a slash command (call in init())
-func RegisterCommand(definition *discordgo.ApplicationCommand, handler Handler) {
+// For commands with subcommands, handler can be nil (see RegisterSubcommand)
+func RegisterCommand(definition *discordgo.ApplicationCommand, handler Handler, opts ...RouteOption) {
 	registeredCommands = append(registeredCommands, &Command{
 		Definition: definition,
-		Handler:    handler,
+		Route:      newRoute(handler, opts),
 	})
 }
 
 // RegisterComponent registers a component handler (call in init())
-func RegisterComponent(customID string, handler Handler) {
-	componentHandlers[customID] = handler
+// customID can be an exact ID or a pattern like "ticket_close:{id}" (values in ctx.Params)
+func RegisterComponent(customID string, handler Handler, opts ...RouteOption) {
+	componentRouter.Handle(customID, handler, opts...)
 }
 
 // RegisterModal registers a modal submit handler (call in init())
-func RegisterModal(customID string, handler Handler) {
-	modalHandlers[customID] = handler
+// customID can be an exact ID or a pattern like "report_modal:{channel}" (values in ctx.Params)
+func RegisterModal(customID string, handler Handler, opts ...RouteOption) {
+	modalRouter.Handle(customID, handler, opts...)
 }
 
 // ============================================
 // Getters (for bot.go)
 // ============================================
 
-// GetDefinitions returns all command definitions
+// GetDefinitions returns all command definitions (slash commands and context menus),
+// with the name / description localizations of the message catalogs
 func GetDefinitions() []*discordgo.ApplicationCommand {
-	definitions := make([]*discordgo.ApplicationCommand, len(registeredCommands))
-	for i, cmd := range registeredCommands {
-		definitions[i] = cmd.Definition
+	definitions := make([]*discordgo.ApplicationCommand, 0, len(registeredCommands))
+	for _, cmd := range registeredCommands {
+		definition := withSubcommands(cmd.Definition)
+		markAutocomplete(definition)
+		definitions = append(definitions, i18n.LocalizeCommand(withDefaultPermissions(definition, cmd.Route)))
+	}
+	for _, cmd := range registeredUserCommands {
+		definitions = append(definitions, i18n.LocalizeCommand(withDefaultPermissions(cmd.Definition, cmd.Route)))
+	}
+	for _, cmd := range registeredMessageCommands {
+		definitions = append(definitions, i18n.LocalizeCommand(withDefaultPermissions(cmd.Definition, cmd.Route)))
 	}
 	return definitions
 }
 
-// GetHandlers returns a map of command names to handlers
-func GetHandlers() map[string]Handler {
-	handlers := make(map[string]Handler)
+// withDefaultPermissions lets Discord hide commands registered with WithNativePermission.
+// Discord can only express "Administrator", so every level from Server Admin up maps to it;
+// bot admin/owner levels are still checked by the dispatcher.
+func withDefaultPermissions(definition *discordgo.ApplicationCommand, route *Route) *discordgo.ApplicationCommand {
+	if !route.Native || route.Permission < auth.PermissionServerAdmin || definition.DefaultMemberPermissions != nil {
+		return definition
+	}
+
+	var permissions int64 = discordgo.PermissionAdministrator
+	def := *definition
+	def.DefaultMemberPermissions = &permissions
+	return &def
+}
+
+// GetHandlers returns a map of command paths ("ticket", "ticket open", "ticket admin close") to routes
+func GetHandlers() map[string]*Route {
+	handlers := make(map[string]*Route)
 	for _, cmd := range registeredCommands {
-		handlers[cmd.Definition.Name] = cmd.Handler
+		if cmd.Handler != nil {
+			handlers[cmd.Definition.Name] = cmd.Route
+		}
+	}
+	for _, sub := range registeredSubcommands {
+		handlers[strings.Join(sub.Path, " ")] = sub.Route
 	}
 	return handlers
 }
 
-// GetComponentHandlers returns all component handlers
-func GetComponentHandlers() map[string]Handler {
-	return componentHandlers
+// GetComponentRouter returns the component handler router
+func GetComponentRouter() *Router {
+	return componentRouter
 }
 
-// GetModalHandlers returns all modal submit handlers
-func GetModalHandlers() map[string]Handler {
-	return modalHandlers
+// GetModalRouter returns the modal submit handler router
+func GetModalRouter() *Router {
+	return modalRouter
 }
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"testing"
+
+	"discord-bot-template/internal/auth"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestWithDefaultPermissions(t *testing.T) {
+	var custom int64 = discordgo.PermissionManageMessages
+
+	tests := []struct {
+		name     string
+		existing *int64
+		opts     []RouteOption
+		want     int64 // 0: DefaultMemberPermissions stays unset
+	}{
+		{"no permission", nil, nil, 0},
+		{"dispatcher only", nil, []RouteOption{WithPermission(auth.PermissionServerAdmin)}, 0},
+		{"native server admin", nil, []RouteOption{WithPermission(auth.PermissionServerAdmin), WithNativePermission()}, discordgo.PermissionAdministrator},
+		{"native bot owner", nil, []RouteOption{WithPermission(auth.PermissionBotOwner), WithNativePermission()}, discordgo.PermissionAdministrator},
+		{"native without level", nil, []RouteOption{WithNativePermission()}, 0},
+		{"definition wins", &custom, []RouteOption{WithPermission(auth.PermissionServerAdmin), WithNativePermission()}, custom},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			definition := &discordgo.ApplicationCommand{Name: "config", DefaultMemberPermissions: tt.existing}
+			got := withDefaultPermissions(definition, newRoute(nil, tt.opts)).DefaultMemberPermissions
+
+			switch {
+			case tt.want == 0 && got != nil:
+				t.Errorf("DefaultMemberPermissions = %d, want unset", *got)
+			case tt.want != 0 && (got == nil || *got != tt.want):
+				t.Errorf("DefaultMemberPermissions = %v, want %d", got, tt.want)
+			}
+			if definition.DefaultMemberPermissions != tt.existing {
+				t.Errorf("withDefaultPermissions changed the registered definition")
+			}
+		})
+	}
+}
+
+func TestInvalidRegistrationPanics(t *testing.T) {
+	type badOptions struct {
+		Count int `option:"count" max:"0"`
+	}
+
+	tests := []struct {
+		name     string
+		register func()
+	}{
+		{"subcommand path", func() {
+			RegisterSubcommand("ticket", &discordgo.ApplicationCommandOption{Description: "d"}, nil)
+		}},
+		{"typed command tags", func() {
+			RegisterTypedCommand(&discordgo.ApplicationCommand{Name: "bad"}, func(ctx *Context, opts *badOptions) error { return nil })
+		}},
+		{"typed subcommand tags", func() {
+			RegisterTypedSubcommand("bad sub", &discordgo.ApplicationCommandOption{Description: "d"}, func(ctx *Context, opts *badOptions) error { return nil })
+		}},
+		{"wizard name", func() {
+			RegisterWizard(&Wizard{Name: "bad:name"})
+		}},
+		{"wizard step", func() {
+			RegisterWizard(&Wizard{Name: "bad_steps", Steps: []*WizardStep{{Title: "Empty"}}})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("registration didn't panic")
+				}
+			}()
+			tt.register()
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"errors"
+	"log"
+
+	"discord-bot-template/internal/component"
+	"discord-bot-template/internal/embed"
+	"discord-bot-template/internal/i18n"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ============================================
+// Confirmation Dialog
+// ============================================
+
+// Confirm asks the invoking user to confirm an action with an ephemeral
+// Confirm / Cancel prompt and returns their choice. The buttons are disabled
+// once answered; a timeout (DefaultComponentTimeout unless CollectTimeout is
+// given) counts as cancelled.
+//
+//	ok, err := Confirm(ctx, "Delete all 42 tickets?")
+//	if err != nil || !ok {
+//	    return err
+//	}
+//	// ... delete, then ctx.Reply(...) sends the result as a follow-up
+//
+// If the interaction was not answered yet the prompt is the original response,
+// otherwise (including after Defer) it is sent as a follow-up.
+func Confirm(ctx *Context, prompt string, opts ...CollectOption) (bool, error) {
+	confirmID, cancelID := ctx.UniqueID("confirm"), ctx.UniqueID("cancel")
+
+	e := embed.Warning(ctx.T("confirm.title"), prompt)
+	row := component.NewActionRow().
+		AddButton(component.SuccessButton(confirmID, ctx.T("confirm.confirm"))).
+		AddButton(component.DangerButton(cancelID, ctx.T("confirm.cancel"))).
+		Build()
+	components := []discordgo.MessageComponent{row}
+
+	// Remember how to edit the prompt when it times out
+	var editPrompt func(*discordgo.WebhookEdit) error
+	if ctx.Acknowledged() {
+		msg, err := ctx.Followup(&discordgo.WebhookParams{
+			Embeds:     []*discordgo.MessageEmbed{e},
+			Components: components,
+			Flags:      discordgo.MessageFlagsEphemeral,
+		})
+		if err != nil {
+			return false, err
+		}
+		editPrompt = func(edit *discordgo.WebhookEdit) error {
+			_, err := ctx.Session.FollowupMessageEdit(ctx.Interaction.Interaction, msg.ID, edit)
+			return err
+		}
+	} else {
+		err := ctx.Reply(&discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{e},
+			Components: components,
+			Flags:      discordgo.MessageFlagsEphemeral,
+		})
+		if err != nil {
+			return false, err
+		}
+		editPrompt = func(edit *discordgo.WebhookEdit) error {
+			_, err := ctx.Edit(edit)
+			return err
+		}
+	}
+
+	opts = append([]CollectOption{CollectCustomID(confirmID, cancelID)}, opts...)
+	click, err := ctx.AwaitComponent(opts...)
+	if errors.Is(err, ErrCollectTimeout) {
+		timedOut := []*discordgo.MessageEmbed{embed.Info(ctx.T("confirm.timed_out"), prompt)}
+		disabled := confirmComponents(ctx.Translator(), false, false)
+		if err := editPrompt(&discordgo.WebhookEdit{Embeds: &timedOut, Components: &disabled}); err != nil {
+			log.Printf("Failed to disable confirmation for %s: %v", DescribeInteraction(ctx.Interaction), err)
+		}
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	confirmed := click.CustomID() == confirmID
+	result := embed.Info(ctx.T("confirm.cancelled"), prompt)
+	if confirmed {
+		result = embed.Success(ctx.T("confirm.confirmed"), prompt)
+	}
+
+	err = click.Update(&discordgo.InteractionResponseData{
+		Embeds:     []*discordgo.MessageEmbed{result},
+		Components: confirmComponents(ctx.Translator(), confirmed, !confirmed),
+	})
+	return confirmed, err
+}
+
+// confirmComponents renders disabled Confirm / Cancel buttons, highlighting the chosen one
+func confirmComponents(t *i18n.Translator, confirmed, cancelled bool) []discordgo.MessageComponent {
+	confirm := component.NewButton().CustomID("confirm_done").Label(t.T("confirm.confirm")).Disabled()
+	cancel := component.NewButton().CustomID("cancel_done").Label(t.T("confirm.cancel")).Disabled()
+
+	confirm.Secondary()
+	if confirmed {
+		confirm.Success()
+	}
+	cancel.Secondary()
+	if cancelled {
+		cancel.Danger()
+	}
+
+	row := component.NewActionRow().
+		AddButton(confirm.Build()).
+		AddButton(cancel.Build()).
+		Build()
+	return []discordgo.MessageComponent{row}
+}
@@ -0,0 +1,382 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"discord-bot-template/internal/auth"
+	"discord-bot-template/internal/component"
+	"discord-bot-template/internal/embed"
+	"discord-bot-template/internal/i18n"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// InteractionTimeout is how long an interaction token stays valid
+const InteractionTimeout = 15 * time.Minute
+
+// Context is passed to every handler. It wraps the session and interaction,
+// exposes the invoking user / guild / options uniformly (guild or DM), and
+// answers through the embedded Response (Reply, Defer, Edit, Followup, ...).
+//
+// Context is also a context.Context: it is cancelled when the handler returns,
+// when the interaction token expires, or when the bot shuts down.
+//
+//	func PingHandler(ctx *commands.Context) error {
+//	    return ctx.ReplyEmbed(embed.New().Description("Pong, " + ctx.User().Username))
+//	}
+type Context struct {
+	context.Context
+	*Response
+
+	Session     *discordgo.Session
+	Interaction *discordgo.InteractionCreate
+	Params      Params // Values captured from the custom ID pattern (components / modals)
+
+	level          *auth.Permission // Cached permission level
+	translator     *i18n.Translator // Cached translator for the user's / guild's locale
+	autoDeferAfter time.Duration    // Inherited by contexts created by collectors
+}
+
+// NewContext creates a handler context. Call cancel when the handler has finished.
+func NewContext(parent context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) (ctx *Context, cancel context.CancelFunc) {
+	base, cancel := context.WithTimeout(parent, InteractionTimeout)
+	return &Context{
+		Context:     base,
+		Response:    NewResponse(s, i),
+		Session:     s,
+		Interaction: i,
+		Params:      Params{},
+	}, cancel
+}
+
+// StartAutoDefer defers the interaction if it hasn't been answered after the given time.
+// The timer stops when the context is done.
+func (c *Context) StartAutoDefer(after time.Duration) {
+	c.autoDeferAfter = after
+	timer := time.AfterFunc(after, c.AutoDefer)
+	context.AfterFunc(c, func() { timer.Stop() })
+}
+
+// ============================================
+// Invoker / Location
+// ============================================
+
+// User returns the invoking user (works in guilds and DMs)
+func (c *Context) User() *discordgo.User {
+	if c.Interaction.Member != nil && c.Interaction.Member.User != nil {
+		return c.Interaction.Member.User
+	}
+	return c.Interaction.User
+}
+
+// Member returns the invoking member (nil in DMs)
+func (c *Context) Member() *discordgo.Member {
+	return c.Interaction.Member
+}
+
+// GuildID returns the guild ID (empty in DMs)
+func (c *Context) GuildID() string {
+	return c.Interaction.GuildID
+}
+
+// ChannelID returns the channel the interaction was used in
+func (c *Context) ChannelID() string {
+	return c.Interaction.ChannelID
+}
+
+// InDM reports whether the interaction happened outside a guild
+func (c *Context) InDM() bool {
+	return c.Interaction.GuildID == ""
+}
+
+// Locale returns the invoking user's client language
+func (c *Context) Locale() discordgo.Locale {
+	return c.Interaction.Locale
+}
+
+// GuildLocale returns the guild's preferred language (empty in DMs)
+func (c *Context) GuildLocale() discordgo.Locale {
+	if c.Interaction.GuildLocale == nil {
+		return ""
+	}
+	return *c.Interaction.GuildLocale
+}
+
+// Translator returns a translator for the user's language, then the guild's (created once)
+func (c *Context) Translator() *i18n.Translator {
+	if c.translator == nil {
+		c.translator = i18n.New(c.Locale(), c.GuildLocale())
+	}
+	return c.translator
+}
+
+// T returns a message in the user's language (see i18n.Translator.T)
+//
+//	ctx.ReplyEmbed(embed.New().Description(ctx.T("tickets.created", id)))
+func (c *Context) T(key string, args ...interface{}) string {
+	return c.Translator().T(key, args...)
+}
+
+// Plural returns the plural form of a message for n in the user's language (see i18n.Translator.Plural)
+func (c *Context) Plural(key string, n int, args ...interface{}) string {
+	return c.Translator().Plural(key, n, args...)
+}
+
+// Permission returns the invoking user's bot permission level (computed once)
+func (c *Context) Permission() auth.Permission {
+	if c.level == nil {
+		level := auth.CheckInteractionPermission(c.Session, c.Interaction)
+		c.level = &level
+	}
+	return *c.level
+}
+
+// ============================================
+// Options
+// ============================================
+
+// Options returns the options of the invoked (sub)command
+func (c *Context) Options() []*discordgo.ApplicationCommandInteractionDataOption {
+	if !c.isCommand() {
+		return nil
+	}
+	return SubcommandOptions(c.Interaction.ApplicationCommandData())
+}
+
+// Option returns an option by name (nil if not given)
+func (c *Context) Option(name string) *discordgo.ApplicationCommandInteractionDataOption {
+	for _, opt := range c.Options() {
+		if opt.Name == name {
+			return opt
+		}
+	}
+	return nil
+}
+
+// StringOption returns a string option ("" if not given)
+func (c *Context) StringOption(name string) string {
+	if opt := c.Option(name); opt != nil {
+		return opt.StringValue()
+	}
+	return ""
+}
+
+// IntOption returns an integer option (0 if not given)
+func (c *Context) IntOption(name string) int64 {
+	if opt := c.Option(name); opt != nil {
+		return opt.IntValue()
+	}
+	return 0
+}
+
+// FloatOption returns a number option (0 if not given)
+func (c *Context) FloatOption(name string) float64 {
+	if opt := c.Option(name); opt != nil {
+		return opt.FloatValue()
+	}
+	return 0
+}
+
+// BoolOption returns a boolean option (false if not given)
+func (c *Context) BoolOption(name string) bool {
+	if opt := c.Option(name); opt != nil {
+		return opt.BoolValue()
+	}
+	return false
+}
+
+// UserOption returns a user option from the resolved data (nil if not given)
+func (c *Context) UserOption(name string) *discordgo.User {
+	opt := c.Option(name)
+	if opt == nil {
+		return nil
+	}
+	id, _ := opt.Value.(string)
+	if resolved := c.Interaction.ApplicationCommandData().Resolved; resolved != nil && resolved.Users[id] != nil {
+		return resolved.Users[id]
+	}
+	return &discordgo.User{ID: id}
+}
+
+// RoleOption returns a role option from the resolved data (nil if not given)
+func (c *Context) RoleOption(name string) *discordgo.Role {
+	opt := c.Option(name)
+	if opt == nil {
+		return nil
+	}
+	id, _ := opt.Value.(string)
+	if resolved := c.Interaction.ApplicationCommandData().Resolved; resolved != nil {
+		return resolved.Roles[id]
+	}
+	return nil
+}
+
+// ChannelOption returns a channel option from the resolved data (nil if not given)
+func (c *Context) ChannelOption(name string) *discordgo.Channel {
+	opt := c.Option(name)
+	if opt == nil {
+		return nil
+	}
+	id, _ := opt.Value.(string)
+	if resolved := c.Interaction.ApplicationCommandData().Resolved; resolved != nil {
+		return resolved.Channels[id]
+	}
+	return nil
+}
+
+// Bind decodes the command options into a struct (see DecodeOptions for the tags)
+func (c *Context) Bind(dst interface{}) error {
+	if !c.isCommand() {
+		return nil
+	}
+	data := c.Interaction.ApplicationCommandData()
+	return DecodeOptions(SubcommandOptions(data), data.Resolved, dst)
+}
+
+// BindModal decodes a modal submission into a struct (see component.DecodeModal for the tags)
+func (c *Context) BindModal(dst interface{}) error {
+	if c.Interaction.Type != discordgo.InteractionModalSubmit {
+		return nil
+	}
+	return component.DecodeModal(c.Interaction.ModalSubmitData(), dst)
+}
+
+// CustomID returns the custom ID of the clicked component or submitted modal
+func (c *Context) CustomID() string {
+	switch c.Interaction.Type {
+	case discordgo.InteractionMessageComponent:
+		return c.Interaction.MessageComponentData().CustomID
+	case discordgo.InteractionModalSubmit:
+		return c.Interaction.ModalSubmitData().CustomID
+	}
+	return ""
+}
+
+// Values returns the selected values of a select menu
+func (c *Context) Values() []string {
+	if c.Interaction.Type != discordgo.InteractionMessageComponent {
+		return nil
+	}
+	return c.Interaction.MessageComponentData().Values
+}
+
+// State decodes the stored state behind the component's custom ID (see component.NewStateID).
+// It returns false if the state has expired.
+func (c *Context) State(dst interface{}) (bool, error) {
+	return component.LoadState(c.CustomID(), dst)
+}
+
+func (c *Context) isCommand() bool {
+	return c.Interaction.Type == discordgo.InteractionApplicationCommand ||
+		c.Interaction.Type == discordgo.InteractionApplicationCommandAutocomplete
+}
+
+// ============================================
+// Reply Helpers (embed.Builder)
+// ============================================
+
+// ReplyEmbed replies with an embed (public)
+func (c *Context) ReplyEmbed(e *embed.Builder, components ...discordgo.MessageComponent) error {
+	embeds, err := buildEmbed(e)
+	if err != nil {
+		return err
+	}
+	return c.Reply(&discordgo.InteractionResponseData{
+		Embeds:     embeds,
+		Components: components,
+	})
+}
+
+// ReplyEphemeral replies with an embed only the user can see
+func (c *Context) ReplyEphemeral(e *embed.Builder, components ...discordgo.MessageComponent) error {
+	embeds, err := buildEmbed(e)
+	if err != nil {
+		return err
+	}
+	return c.Reply(&discordgo.InteractionResponseData{
+		Embeds:     embeds,
+		Components: components,
+		Flags:      discordgo.MessageFlagsEphemeral,
+	})
+}
+
+// UpdateEmbed replaces the embed of the message a component is attached to
+// (its components are replaced only if some are given)
+func (c *Context) UpdateEmbed(e *embed.Builder, components ...discordgo.MessageComponent) error {
+	embeds, err := buildEmbed(e)
+	if err != nil {
+		return err
+	}
+	return c.Update(&discordgo.InteractionResponseData{
+		Embeds:     embeds,
+		Components: components,
+	})
+}
+
+// EditEmbed edits the original response to show the embed
+func (c *Context) EditEmbed(e *embed.Builder) error {
+	embeds, err := buildEmbed(e)
+	if err != nil {
+		return err
+	}
+	_, err = c.Edit(&discordgo.WebhookEdit{Embeds: &embeds})
+	return err
+}
+
+// FollowupEmbed sends an additional message with the embed
+func (c *Context) FollowupEmbed(e *embed.Builder, ephemeral bool) (*discordgo.Message, error) {
+	embeds, err := buildEmbed(e)
+	if err != nil {
+		return nil, err
+	}
+	params := &discordgo.WebhookParams{Embeds: embeds}
+	if ephemeral {
+		params.Flags = discordgo.MessageFlagsEphemeral
+	}
+	return c.Followup(params)
+}
+
+// Template renders an embed template (see embed.Render) with the invoking user, member and guild
+func (c *Context) Template(name string, data interface{}) (*embed.Builder, error) {
+	td := embed.TemplateData{User: c.User(), Member: c.Member(), Data: data}
+	if c.GuildID() != "" && c.Session.State != nil {
+		if guild, err := c.Session.State.Guild(c.GuildID()); err == nil {
+			td.Guild = guild
+		}
+	}
+	return embed.Render(name, td)
+}
+
+// ReplyEmbeds sends any number of embeds (e.g. from embed.Split), as many messages as needed:
+// the first batch is the reply, the rest are follow-ups
+func (c *Context) ReplyEmbeds(embeds []*discordgo.MessageEmbed, ephemeral bool) error {
+	var flags discordgo.MessageFlags
+	if ephemeral {
+		flags = discordgo.MessageFlagsEphemeral
+	}
+
+	for idx, batch := range embed.Batch(embeds) {
+		if idx == 0 {
+			err := c.Reply(&discordgo.InteractionResponseData{Embeds: batch, Flags: flags})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := c.Followup(&discordgo.WebhookParams{Embeds: batch, Flags: flags}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildEmbed checks the embed against Discord's limits before sending,
+// so the handler gets a descriptive error instead of a rejected API call
+func buildEmbed(e *embed.Builder) ([]*discordgo.MessageEmbed, error) {
+	embeds := e.BuildSlice()
+	if err := embed.Validate(embeds[0]); err != nil {
+		return nil, err
+	}
+	return embeds, nil
+}
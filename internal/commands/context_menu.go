@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// UserCommandHandler handles a user context menu command (右鍵用戶 → Apps)
+// member is nil when the command is used outside a guild.
+type UserCommandHandler func(ctx *Context, user *discordgo.User, member *discordgo.Member) error
+
+// MessageCommandHandler handles a message context menu command (右鍵訊息 → Apps)
+type MessageCommandHandler func(ctx *Context, message *discordgo.Message) error
+
+// ============================================
+// Auto-registration (使用 init() 自動註冊)
+// ============================================
+
+var registeredUserCommands []*Command
+var registeredMessageCommands []*Command
+
+// RegisterUserCommand registers a user context menu command (call in init())
+func RegisterUserCommand(name string, handler UserCommandHandler, opts ...RouteOption) {
+	registeredUserCommands = append(registeredUserCommands, &Command{
+		Definition: &discordgo.ApplicationCommand{
+			Type: discordgo.UserApplicationCommand,
+			Name: name,
+		},
+		Route: newRoute(func(ctx *Context) error {
+			data := ctx.Interaction.ApplicationCommandData()
+			user, member := resolveTargetUser(data)
+			if user == nil {
+				return fmt.Errorf("user command %q: target %s not resolved", name, data.TargetID)
+			}
+			return handler(ctx, user, member)
+		}, opts),
+	})
+}
+
+// RegisterMessageCommand registers a message context menu command (call in init())
+func RegisterMessageCommand(name string, handler MessageCommandHandler, opts ...RouteOption) {
+	registeredMessageCommands = append(registeredMessageCommands, &Command{
+		Definition: &discordgo.ApplicationCommand{
+			Type: discordgo.MessageApplicationCommand,
+			Name: name,
+		},
+		Route: newRoute(func(ctx *Context) error {
+			data := ctx.Interaction.ApplicationCommandData()
+			if data.Resolved == nil || data.Resolved.Messages[data.TargetID] == nil {
+				return fmt.Errorf("message command %q: target %s not resolved", name, data.TargetID)
+			}
+			return handler(ctx, data.Resolved.Messages[data.TargetID])
+		}, opts),
+	})
+}
+
+// resolveTargetUser returns the target user and (in guilds) member
+func resolveTargetUser(data discordgo.ApplicationCommandInteractionData) (*discordgo.User, *discordgo.Member) {
+	if data.Resolved == nil {
+		return nil, nil
+	}
+
+	user := data.Resolved.Users[data.TargetID]
+	member := data.Resolved.Members[data.TargetID]
+	if member != nil {
+		// Resolved members don't include the user object
+		m := *member
+		m.User = user
+		member = &m
+	}
+	return user, member
+}
+
+// ============================================
+// Getters (for bot.go)
+// ============================================
+
+// GetUserCommandHandlers returns user context menu routes keyed by name
+func GetUserCommandHandlers() map[string]*Route {
+	return routesByName(registeredUserCommands)
+}
+
+// GetMessageCommandHandlers returns message context menu routes keyed by name
+func GetMessageCommandHandlers() map[string]*Route {
+	return routesByName(registeredMessageCommands)
+}
+
+func routesByName(cmds []*Command) map[string]*Route {
+	routes := make(map[string]*Route)
+	for _, cmd := range cmds {
+		routes[cmd.Definition.Name] = cmd.Route
+	}
+	return routes
+}
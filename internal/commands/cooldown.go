@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"sync"
+	"time"
+
+	"discord-bot-template/internal/auth"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// CooldownScope decides who shares a cooldown bucket
+type CooldownScope int
+
+const (
+	CooldownUser    CooldownScope = iota // Each user has their own bucket
+	CooldownGuild                        // Everyone in a guild shares a bucket
+	CooldownChannel                      // Everyone in a channel shares a bucket
+	CooldownGlobal                       // One bucket for everyone
+)
+
+// Cooldown allows Burst uses per Window for each bucket (sliding window).
+// Users with auth.PermissionBotAdmin or above are exempt.
+type Cooldown struct {
+	Scope  CooldownScope
+	Window time.Duration
+	Burst  int
+
+	mu        sync.Mutex
+	buckets   map[string][]time.Time
+	lastSweep time.Time
+}
+
+// WithCooldown rate limits a command/component/modal
+//
+//	RegisterCommand(def, handler, WithCooldown(CooldownUser, 30*time.Second, 2)) // 2 uses per 30s per user
+func WithCooldown(scope CooldownScope, window time.Duration, burst int) RouteOption {
+	if burst < 1 {
+		burst = 1
+	}
+	cooldown := &Cooldown{
+		Scope:   scope,
+		Window:  window,
+		Burst:   burst,
+		buckets: make(map[string][]time.Time),
+	}
+	return func(r *Route) {
+		r.Cooldown = cooldown
+	}
+}
+
+// Take records a use for the interaction's bucket.
+// If the bucket is full it returns false and the time the next use is allowed.
+func (c *Cooldown) Take(i *discordgo.InteractionCreate) (time.Time, bool) {
+	now := time.Now()
+	key := c.bucketKey(i)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sweep(now)
+
+	hits := c.prune(c.buckets[key], now)
+	if len(hits) >= c.Burst {
+		c.buckets[key] = hits
+		return hits[0].Add(c.Window), false
+	}
+
+	c.buckets[key] = append(hits, now)
+	return time.Time{}, true
+}
+
+// bucketKey returns the bucket for the interaction according to the scope
+func (c *Cooldown) bucketKey(i *discordgo.InteractionCreate) string {
+	switch c.Scope {
+	case CooldownGuild:
+		if i.GuildID != "" {
+			return i.GuildID
+		}
+		// DMs have no guild, fall back to the user
+		return auth.InteractionUserID(i)
+	case CooldownChannel:
+		return i.ChannelID
+	case CooldownGlobal:
+		return ""
+	}
+	return auth.InteractionUserID(i)
+}
+
+// prune drops hits older than the window
+func (c *Cooldown) prune(hits []time.Time, now time.Time) []time.Time {
+	cutoff := now.Add(-c.Window)
+	idx := 0
+	for idx < len(hits) && !hits[idx].After(cutoff) {
+		idx++
+	}
+	return hits[idx:]
+}
+
+// sweep removes expired buckets so the map doesn't grow forever (caller holds the lock)
+func (c *Cooldown) sweep(now time.Time) {
+	if now.Sub(c.lastSweep) < time.Minute {
+		return
+	}
+	c.lastSweep = now
+
+	for key, hits := range c.buckets {
+		if hits = c.prune(hits, now); len(hits) == 0 {
+			delete(c.buckets, key)
+		} else {
+			c.buckets[key] = hits
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"discord-bot-template/internal/auth"
+	"discord-bot-template/internal/embed"
+	"discord-bot-template/internal/i18n"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// UserError is an error whose message is safe to show to the user.
+// Other errors returned by handlers are logged and shown as a generic message.
+type UserError struct {
+	Title   string
+	Message string
+}
+
+func (e *UserError) Error() string {
+	return e.Message
+}
+
+// UserErrorf creates a UserError with a formatted message
+//
+//	return commands.UserErrorf("Ticket #%d does not exist", id)
+func UserErrorf(format string, args ...interface{}) error {
+	return &UserError{Message: fmt.Sprintf(format, args...)}
+}
+
+// ErrorEmbed returns the embed shown to the user for a handler error.
+// UserError and i18n errors are shown, anything else as a generic message.
+func ErrorEmbed(t *i18n.Translator, err error) *discordgo.MessageEmbed {
+	var userErr *UserError
+	if errors.As(err, &userErr) {
+		title := userErr.Title
+		if title == "" {
+			title = t.T("errors.title")
+		}
+		return embed.Error(title, userErr.Message)
+	}
+	var localized *i18n.Error
+	if errors.As(err, &localized) {
+		return embed.Error(t.T("errors.title"), t.Error(localized))
+	}
+	return embed.Error(t.T("errors.generic.title"), t.T("errors.generic.message"))
+}
+
+// PermissionDeniedEmbed returns the standard embed shown when a user lacks the required level
+func PermissionDeniedEmbed(t *i18n.Translator, required auth.Permission) *discordgo.MessageEmbed {
+	return embed.Error(t.T("errors.permission_denied.title"), t.T("errors.permission_denied.message", embed.Bold(PermissionName(t, required))))
+}
+
+// PermissionName returns the display name of a level in the translator's language
+// ("permissions.levels.<config name>", falling back to Permission.String)
+func PermissionName(t *i18n.Translator, level auth.Permission) string {
+	name, err := level.MarshalText()
+	if err != nil || !t.Has("permissions.levels."+string(name)) {
+		return level.String()
+	}
+	return t.T("permissions.levels." + string(name))
+}
+
+// CooldownEmbed returns the standard embed shown when a route is on cooldown
+func CooldownEmbed(t *i18n.Translator, retryAt time.Time) *discordgo.MessageEmbed {
+	return embed.Warning(t.T("errors.cooldown.title"), t.T("errors.cooldown.message", embed.RelativeTime(retryAt)))
+}
+
+// ReplyError sends an ephemeral embed as the interaction response,
+// or as a follow-up message if the interaction was already answered
+func ReplyError(resp *Response, e *discordgo.MessageEmbed) error {
+	err := resp.Reply(&discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{e},
+		Flags:  discordgo.MessageFlagsEphemeral,
+	})
+	if err == nil {
+		return nil
+	}
+
+	// Acknowledged outside the Response helper (s.InteractionRespond)
+	_, err = resp.Followup(&discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{e},
+		Flags:  discordgo.MessageFlagsEphemeral,
+	})
+	return err
+}
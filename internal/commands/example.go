@@ -1,7 +1,10 @@
 package commands
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
 	"strings"
 	"time"
 
@@ -32,8 +35,13 @@ func init() {
 	RegisterComponent("example_user_select", ExampleUserSelectHandler)
 
 	// Modal 範例
+	// 送出的 Modal 由 ctx.AwaitModal 直接接收，不需要 RegisterModal
 	RegisterComponent("example_open_modal", ExampleOpenModalHandler)
-	RegisterModal("example_modal", ExampleModalSubmitHandler)
+	RegisterComponent("example_open_wizard", ExampleOpenWizardHandler)
+	RegisterWizard(exampleWizard)
+
+	// Paginator 範例
+	RegisterComponent("example_paginator", ExamplePaginatorHandler)
 }
 
 var exampleCommand = &discordgo.ApplicationCommand{
@@ -53,6 +61,8 @@ func buildNavSelect(current string) discordgo.ActionsRow {
 		AddOptionWithEmoji("Buttons", "buttons", "All button styles", "🔘").
 		AddOptionWithEmoji("Select Menus", "selects", "Dropdown menus", "📋").
 		AddOptionWithEmoji("Modal Form", "modal", "Popup form demo", "📝").
+		AddOptionWithEmoji("Paginator", "paginator", "Multi-page embeds", "📖").
+		AddOptionWithEmoji("Templates", "templates", "Embeds loaded from files", "🗂️").
 		Build()
 
 	// Mark current as default
@@ -164,8 +174,10 @@ func buildModalPage() (*discordgo.MessageEmbed, []discordgo.MessageComponent) {
 			"• Short text input (single line)\n"+
 			"• Paragraph input (multi-line)\n"+
 			"• Required/Optional fields\n"+
-			"• Min/Max length validation\n\n"+
-			"Click the button below to try it!").
+			"• Min/Max length validation\n"+
+			"• Custom validators (URL, numbers, ...) with retry\n"+
+			"• Multi-step wizards with conditional steps\n\n"+
+			"Click a button below to try it!").
 		Color(embed.ColorGold).
 		Build()
 
@@ -177,69 +189,113 @@ func buildModalPage() (*discordgo.MessageEmbed, []discordgo.MessageComponent) {
 		Primary().
 		Emoji("📝").
 		Build()
+	wizardBtn := component.NewButton().
+		CustomID("example_open_wizard").
+		Label("Open Wizard").
+		Secondary().
+		Emoji("🧙").
+		Build()
+	btnRow := component.NewActionRow().
+		AddButton(openBtn).
+		AddButton(wizardBtn).
+		Build()
+
+	return e, []discordgo.MessageComponent{nav, btnRow}
+}
+
+func buildPaginatorPage() (*discordgo.MessageEmbed, []discordgo.MessageComponent) {
+	e := embed.New().
+		Title("Paginator Demo").
+		Description("Paginators show one embed at a time with navigation buttons.\n\n"+
+			"**Features:**\n"+
+			"• First / Previous / Next / Last buttons\n"+
+			"• Click the page counter to jump to a page\n"+
+			"• Only you can use your paginator\n"+
+			"• Buttons are disabled after 3 minutes of inactivity\n\n"+
+			"Click the button below to try it!").
+		Color(embed.ColorTeal).
+		Build()
+
+	nav := buildNavSelect("paginator")
+
+	openBtn := component.NewButton().
+		CustomID("example_paginator").
+		Label("Open Paginator").
+		Primary().
+		Emoji("📖").
+		Build()
 	btnRow := component.SingleButtonRow(openBtn)
 
 	return e, []discordgo.MessageComponent{nav, btnRow}
 }
 
+func buildTemplatesPage(ctx *Context) (*discordgo.MessageEmbed, []discordgo.MessageComponent) {
+	nav := buildNavSelect("templates")
+
+	// templates/help.yaml, edit it while the bot is running to see it reload
+	b, err := ctx.Template("help", nil)
+	if err != nil {
+		log.Printf("Failed to render help template: %v", err)
+		return embed.Warning("Template unavailable", "Could not render "+embed.InlineCode("templates/help.yaml")+"."),
+			[]discordgo.MessageComponent{nav}
+	}
+
+	return b.Build(), []discordgo.MessageComponent{nav}
+}
+
 // ============================================
 // Handlers
 // ============================================
 
-func ExampleHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	e, components := buildEmbedPage(i.Member.User)
+func ExampleHandler(ctx *Context) error {
+	e, components := buildEmbedPage(ctx.User())
 
-	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Embeds:     []*discordgo.MessageEmbed{e},
-			Components: components,
-		},
+	return ctx.Reply(&discordgo.InteractionResponseData{
+		Embeds:     []*discordgo.MessageEmbed{e},
+		Components: components,
 	})
 }
 
-func ExampleNavHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	selected := i.MessageComponentData().Values[0]
+func ExampleNavHandler(ctx *Context) error {
+	selected := ctx.Values()[0]
 
 	var e *discordgo.MessageEmbed
 	var components []discordgo.MessageComponent
 
 	switch selected {
 	case "embed":
-		e, components = buildEmbedPage(i.Member.User)
+		e, components = buildEmbedPage(ctx.User())
 	case "buttons":
 		e, components = buildButtonsPage()
 	case "selects":
 		e, components = buildSelectsPage()
 	case "modal":
 		e, components = buildModalPage()
+	case "paginator":
+		e, components = buildPaginatorPage()
+	case "templates":
+		e, components = buildTemplatesPage(ctx)
 	default:
-		e, components = buildEmbedPage(i.Member.User)
+		e, components = buildEmbedPage(ctx.User())
 	}
 
-	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseUpdateMessage,
-		Data: &discordgo.InteractionResponseData{
-			Embeds:     []*discordgo.MessageEmbed{e},
-			Components: components,
-		},
+	return ctx.Update(&discordgo.InteractionResponseData{
+		Embeds:     []*discordgo.MessageEmbed{e},
+		Components: components,
 	})
 }
 
-func ExampleReloadHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	e, components := buildEmbedPage(i.Member.User)
+func ExampleReloadHandler(ctx *Context) error {
+	e, components := buildEmbedPage(ctx.User())
 
-	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseUpdateMessage,
-		Data: &discordgo.InteractionResponseData{
-			Embeds:     []*discordgo.MessageEmbed{e},
-			Components: components,
-		},
+	return ctx.Update(&discordgo.InteractionResponseData{
+		Embeds:     []*discordgo.MessageEmbed{e},
+		Components: components,
 	})
 }
 
-func ExampleButtonHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	buttonID := i.MessageComponentData().CustomID
+func ExampleButtonHandler(ctx *Context) error {
+	buttonID := ctx.CustomID()
 
 	styleMap := map[string]string{
 		"example_primary":   "Primary",
@@ -250,22 +306,23 @@ func ExampleButtonHandler(s *discordgo.Session, i *discordgo.InteractionCreate)
 
 	style := styleMap[buttonID]
 
+	// Dangerous actions ask first
+	if buttonID == "example_danger" {
+		ok, err := Confirm(ctx, "Do you really want to click the **Danger** button?")
+		if err != nil || !ok {
+			return err
+		}
+	}
+
 	e := embed.New().
 		Description(fmt.Sprintf("You clicked the **%s** button!", style)).
-		Color(embed.ColorBlurple).
-		Build()
+		Color(embed.ColorBlurple)
 
-	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Embeds: []*discordgo.MessageEmbed{e},
-			Flags:  discordgo.MessageFlagsEphemeral,
-		},
-	})
+	return ctx.ReplyEphemeral(e)
 }
 
-func ExampleColorSelectHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	selected := i.MessageComponentData().Values[0]
+func ExampleColorSelectHandler(ctx *Context) error {
+	selected := ctx.Values()[0]
 
 	colorMap := map[string]int{
 		"red":    embed.ColorRed,
@@ -278,85 +335,132 @@ func ExampleColorSelectHandler(s *discordgo.Session, i *discordgo.InteractionCre
 
 	e := embed.New().
 		Description(fmt.Sprintf("You selected **%s**!", colorName)).
-		Color(colorMap[selected]).
-		Build()
+		Color(colorMap[selected])
 
-	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Embeds: []*discordgo.MessageEmbed{e},
-			Flags:  discordgo.MessageFlagsEphemeral,
-		},
-	})
+	return ctx.ReplyEphemeral(e)
 }
 
-func ExampleUserSelectHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	userID := i.MessageComponentData().Values[0]
+func ExampleUserSelectHandler(ctx *Context) error {
+	userID := ctx.Values()[0]
 
 	e := embed.New().
 		Description(fmt.Sprintf("You selected %s!", embed.Mention(userID))).
-		Color(embed.ColorBlurple).
-		Build()
+		Color(embed.ColorBlurple)
 
-	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Embeds: []*discordgo.MessageEmbed{e},
-			Flags:  discordgo.MessageFlagsEphemeral,
-		},
-	})
+	return ctx.ReplyEphemeral(e)
 }
 
-func ExampleOpenModalHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	titleInput := component.NewTextInput().
-		CustomID("modal_title").
-		Label("Title").
-		Placeholder("Enter a title...").
-		Short().
-		Required().
-		MaxLength(100).
-		Build()
+// exampleForm is decoded from the example modal's inputs
+type exampleForm struct {
+	Title   string `input:"modal_title,required" label:"Title" placeholder:"Enter a title..." maxlen:"100"`
+	Message string `input:"modal_message,required,paragraph" label:"Message" placeholder:"Enter your message..." minlen:"10" maxlen:"500"`
+	Website string `input:"modal_website" label:"Website" placeholder:"https://..."`
+	Rating  *int   `input:"modal_rating" label:"Rating (1-5)" placeholder:"5"`
+}
 
-	messageInput := component.NewTextInput().
-		CustomID("modal_message").
-		Label("Message").
-		Placeholder("Enter your message...").
-		Paragraph().
-		Required().
-		MinLength(10).
-		MaxLength(500).
-		Build()
+func ExampleOpenModalHandler(ctx *Context) error {
+	form, err := component.ModalFor(ctx.UniqueID("example_modal"), "Example Form", exampleForm{})
+	if err != nil {
+		return err
+	}
 
-	modal := component.NewModal().
-		CustomID("example_modal").
-		Title("Example Form").
-		AddTextInput(titleInput).
-		AddTextInput(messageInput).
+	// Checked when submitted, invalid answers get a "Try again" button
+	modal := form.
+		Validate("modal_website", component.URL()).
+		Validate("modal_rating", component.IntRange(1, 5)).
 		Build()
 
-	s.InteractionRespond(i.Interaction, modal)
-}
-
-func ExampleModalSubmitHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	data := i.ModalSubmitData()
+	submit, err := ctx.AwaitModal(modal)
+	if errors.Is(err, ErrCollectTimeout) || errors.Is(err, context.Canceled) {
+		return nil // Closed without submitting
+	}
+	if err != nil {
+		return err
+	}
 
-	title := component.GetModalValue(data, "modal_title")
-	message := component.GetModalValue(data, "modal_message")
+	var answers exampleForm
+	if err := submit.BindModal(&answers); err != nil {
+		return err
+	}
 
 	e := embed.New().
 		Title("Form Submitted!").
 		Color(embed.ColorSuccess).
-		BlockField("Title", title).
-		BlockField("Message", message).
-		Footer(fmt.Sprintf("By %s", i.Member.User.Username), i.Member.User.AvatarURL("32")).
-		Timestamp().
-		Build()
+		Sanitize(). // Answers and username are user input
+		BlockField("Title", answers.Title).
+		BlockField("Message", answers.Message)
+	if answers.Website != "" {
+		e.BlockField("Website", answers.Website)
+	}
+	if answers.Rating != nil {
+		e.InlineField("Rating", strings.Repeat("⭐", *answers.Rating))
+	}
+	e.Footer(fmt.Sprintf("By %s", submit.User().Username), submit.User().AvatarURL("32")).
+		Timestamp()
 
-	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Embeds: []*discordgo.MessageEmbed{e},
-			Flags:  discordgo.MessageFlagsEphemeral,
+	return submit.ReplyEphemeral(e)
+}
+
+// exampleWizard asks about pets only if the user has one
+var exampleWizard = &Wizard{
+	Name: "example",
+	Steps: []*WizardStep{
+		{
+			Title: "About You",
+			Inputs: []discordgo.TextInput{
+				component.ShortInput("name", "Name", "Your name"),
+				component.ShortInput("has_pet", "Do you have a pet? (yes/no)", "yes or no"),
+			},
+			Validate: map[string][]component.Validator{
+				"has_pet": {component.OneOf("yes", "no")},
+			},
 		},
-	})
+		{
+			Title: "Your Pet",
+			Inputs: []discordgo.TextInput{
+				component.ShortInput("pet_name", "Pet name", "e.g. Mochi"),
+				component.OptionalShortInput("pet_kind", "What kind of animal?", "e.g. Cat"),
+			},
+			When: func(answers WizardAnswers) bool {
+				return strings.HasPrefix(strings.ToLower(strings.TrimSpace(answers["has_pet"])), "y")
+			},
+		},
+		{
+			Title: "Feedback",
+			Inputs: []discordgo.TextInput{
+				component.OptionalParagraphInput("feedback", "Anything else?", "Your thoughts..."),
+			},
+		},
+	},
+	OnComplete: func(ctx *Context, answers WizardAnswers) error {
+		e := embed.New().
+			Title("Wizard Completed!").
+			Color(embed.ColorSuccess).
+			Sanitize().
+			InlineField("Name", answers["name"]).
+			InlineField("Has pet", answers["has_pet"])
+		if answers["pet_name"] != "" {
+			e.InlineField("Pet", strings.TrimSpace(answers["pet_name"]+" "+answers["pet_kind"]))
+		}
+		if answers["feedback"] != "" {
+			e.BlockField("Feedback", answers["feedback"])
+		}
+		return ctx.ReplyEphemeral(e)
+	},
+}
+
+func ExampleOpenWizardHandler(ctx *Context) error {
+	return exampleWizard.Start(ctx)
+}
+
+func ExamplePaginatorHandler(ctx *Context) error {
+	colors := []int{embed.ColorRed, embed.ColorOrange, embed.ColorYellow, embed.ColorGreen, embed.ColorBlue, embed.ColorPurple}
+
+	return component.NewPaginatorFunc(len(colors), func(page int) (*discordgo.MessageEmbed, error) {
+		return embed.New().
+			Title(fmt.Sprintf("Page %d", page+1)).
+			Description(fmt.Sprintf("This is page %d of %d.\nPages are built on demand.", page+1, len(colors))).
+			Color(colors[page]).
+			Build(), nil
+	}).Ephemeral().Send(ctx)
 }
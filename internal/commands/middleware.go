@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"log"
+	"time"
+
+	"discord-bot-template/internal/auth"
+	"discord-bot-template/internal/component"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Middleware wraps a Handler (logging, permission checks, recovery, ...)
+type Middleware func(Handler) Handler
+
+// Route is a registered handler together with its per-registration settings
+type Route struct {
+	Handler     Handler
+	Middlewares []Middleware
+	Permission  auth.Permission                // Required level, checked by the dispatcher before the handler runs
+	Native      bool                           // Also hide the command in Discord (see WithNativePermission)
+	Cooldown    *Cooldown                      // Rate limit, checked by the dispatcher after the permission check
+	Validators  map[string]component.InputRule // Modal input validators by input custom ID (modals only)
+}
+
+// RouteOption configures a route at registration time
+//
+//	RegisterCommand(def, handler, WithMiddleware(myMiddleware))
+type RouteOption func(*Route)
+
+// WithMiddleware attaches middlewares to a single command/component/modal
+func WithMiddleware(middlewares ...Middleware) RouteOption {
+	return func(r *Route) {
+		r.Middlewares = append(r.Middlewares, middlewares...)
+	}
+}
+
+// WithPermission requires a permission level (or higher) to use the command/component/modal.
+// Commands stay visible in Discord: levels can be granted per server (see /permissions),
+// which Discord's DefaultMemberPermissions knows nothing about.
+func WithPermission(level auth.Permission) RouteOption {
+	return func(r *Route) {
+		r.Permission = level
+	}
+}
+
+// WithNativePermission lets Discord enforce the required level too, by setting the command's
+// DefaultMemberPermissions to Administrator (levels from Server Admin up). Discord knows nothing
+// about /permissions grants, so use it only for commands granted members don't need to see.
+//
+//	RegisterCommand(configCommand, ConfigHandler, WithPermission(auth.PermissionServerAdmin), WithNativePermission())
+func WithNativePermission() RouteOption {
+	return func(r *Route) {
+		r.Native = true
+	}
+}
+
+// WithValidators validates a text input of a registered modal before its handler runs
+// (see RejectInvalidModal). Unlike validators given to the modal's builder, these also
+// apply to modals built before a restart.
+//
+//	RegisterModal("profile", ProfileHandler, WithValidators("age", "Age", component.IntRange(13, 120)))
+func WithValidators(inputID, label string, validators ...component.Validator) RouteOption {
+	return func(r *Route) {
+		if r.Validators == nil {
+			r.Validators = make(map[string]component.InputRule)
+		}
+		rule := r.Validators[inputID]
+		rule.Label = label
+		rule.Validators = append(rule.Validators, validators...)
+		r.Validators[inputID] = rule
+	}
+}
+
+// newRoute creates a route and applies its options
+func newRoute(handler Handler, opts []RouteOption) *Route {
+	route := &Route{Handler: handler}
+	for _, opt := range opts {
+		opt(route)
+	}
+	return route
+}
+
+// Chain wraps handler with middlewares; the first middleware is the outermost
+func Chain(handler Handler, middlewares ...Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// ============================================
+// Built-in Middlewares
+// ============================================
+
+// LogInteractions logs every handled interaction with its user and duration
+func LogInteractions() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx *Context) error {
+			start := time.Now()
+			err := next(ctx)
+			log.Printf("Handled %s for %s in %s", DescribeInteraction(ctx.Interaction), ctx.User().ID, time.Since(start))
+			return err
+		}
+	}
+}
+
+// DescribeInteraction returns a short label like "/ticket open" or "component ticket_close:1"
+func DescribeInteraction(i *discordgo.InteractionCreate) string {
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand, discordgo.InteractionApplicationCommandAutocomplete:
+		return "/" + CommandPath(i.ApplicationCommandData())
+	case discordgo.InteractionMessageComponent:
+		return "component " + i.MessageComponentData().CustomID
+	case discordgo.InteractionModalSubmit:
+		return "modal " + i.ModalSubmitData().CustomID
+	}
+	return i.Type.String()
+}
@@ -0,0 +1,442 @@
+package commands
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"discord-bot-template/internal/i18n"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ============================================
+// Typed Options (struct tag 綁定)
+// ============================================
+//
+// Declare options as a struct:
+//
+//	type BanOptions struct {
+//	    User   *discordgo.User `option:"user,required" description:"User to ban"`
+//	    Days   int             `option:"days" description:"Days of messages to delete" min:"0" max:"7"`
+//	    Reason string          `option:"reason" description:"Reason" maxlen:"512"`
+//	    Mode   string          `option:"mode" description:"Ban mode" choices:"Soft=soft,Hard=hard"`
+//	    Log    *discordgo.Channel `option:"log" description:"Log channel" channel:"text,news"`
+//	}
+//
+// Supported tags:
+//   - option:        name[,required][,autocomplete]
+//   - description:   option description (defaults to the name)
+//   - min / max:     numeric range (int / float fields)
+//   - minlen/maxlen: string length range
+//   - choices:       "Label=value,Label2=value2" (or just "a,b,c")
+//   - channel:       allowed channel types (text, voice, category, news, stage, forum, thread, ...)
+//
+// Supported field types: string, int*, float*, bool (or pointers to them for optional
+// presence checks), *discordgo.User, *discordgo.Member, *discordgo.Role,
+// *discordgo.Channel, *discordgo.MessageAttachment.
+// Fields of embedded structs are included, so option sets can be shared.
+
+// TypedHandler is a handler that receives decoded options
+type TypedHandler[T any] func(ctx *Context, options *T) error
+
+var (
+	userType       = reflect.TypeOf(&discordgo.User{})
+	memberType     = reflect.TypeOf(&discordgo.Member{})
+	roleType       = reflect.TypeOf(&discordgo.Role{})
+	channelType    = reflect.TypeOf(&discordgo.Channel{})
+	attachmentType = reflect.TypeOf(&discordgo.MessageAttachment{})
+)
+
+var channelTypeNames = map[string]discordgo.ChannelType{
+	"text":           discordgo.ChannelTypeGuildText,
+	"voice":          discordgo.ChannelTypeGuildVoice,
+	"category":       discordgo.ChannelTypeGuildCategory,
+	"news":           discordgo.ChannelTypeGuildNews,
+	"announcement":   discordgo.ChannelTypeGuildNews,
+	"stage":          discordgo.ChannelTypeGuildStageVoice,
+	"forum":          discordgo.ChannelTypeGuildForum,
+	"thread":         discordgo.ChannelTypeGuildPublicThread,
+	"public_thread":  discordgo.ChannelTypeGuildPublicThread,
+	"private_thread": discordgo.ChannelTypeGuildPrivateThread,
+	"news_thread":    discordgo.ChannelTypeGuildNewsThread,
+}
+
+// RegisterTypedCommand registers a slash command whose options are generated from T
+// and decoded into a *T before the handler runs (call in init()). Invalid tags panic.
+func RegisterTypedCommand[T any](definition *discordgo.ApplicationCommand, handler TypedHandler[T], opts ...RouteOption) {
+	options, err := OptionsFor[T]()
+	if err != nil {
+		panic(fmt.Sprintf("register /%s: %v", definition.Name, err))
+	}
+
+	definition.Options = options
+	RegisterCommand(definition, bindOptions(handler), opts...)
+}
+
+// RegisterTypedSubcommand registers a subcommand whose options are generated from T (call in init()).
+// Invalid tags panic.
+func RegisterTypedSubcommand[T any](path string, option *discordgo.ApplicationCommandOption, handler TypedHandler[T], opts ...RouteOption) {
+	options, err := OptionsFor[T]()
+	if err != nil {
+		panic(fmt.Sprintf("register /%s: %v", path, err))
+	}
+
+	opt := *option
+	opt.Options = options
+	RegisterSubcommand(path, &opt, bindOptions(handler), opts...)
+}
+
+// RegisterTypedModal registers a modal handler that receives the submission decoded into a *T
+// (see component.ModalFor for building the modal from the same struct; call in init())
+func RegisterTypedModal[T any](pattern string, handler TypedHandler[T], opts ...RouteOption) {
+	RegisterModal(pattern, func(ctx *Context) error {
+		var form T
+		if err := ctx.BindModal(&form); err != nil {
+			return &UserError{Title: ctx.T("errors.invalid_input"), Message: ctx.Translator().Error(err)}
+		}
+
+		return handler(ctx, &form)
+	}, opts...)
+}
+
+// bindOptions decodes the interaction options into a new *T before calling the handler
+func bindOptions[T any](handler TypedHandler[T]) Handler {
+	return func(ctx *Context) error {
+		var options T
+		if err := ctx.Bind(&options); err != nil {
+			return &UserError{Title: ctx.T("errors.invalid_options"), Message: ctx.Translator().Error(err)}
+		}
+
+		return handler(ctx, &options)
+	}
+}
+
+// ============================================
+// Definition Generation
+// ============================================
+
+// OptionsFor generates command option definitions from the struct type T
+func OptionsFor[T any]() ([]*discordgo.ApplicationCommandOption, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("options type %s is not a struct", t)
+	}
+
+	var options []*discordgo.ApplicationCommandOption
+	for _, field := range reflect.VisibleFields(t) {
+		tag, ok := parseOptionTag(field)
+		if !ok {
+			continue
+		}
+
+		opt, err := buildOption(field, tag)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		options = append(options, opt)
+	}
+
+	// Discord requires required options to come before optional ones
+	sort.SliceStable(options, func(a, b int) bool {
+		return options[a].Required && !options[b].Required
+	})
+
+	return options, nil
+}
+
+// optionTag is the parsed form of `option:"name,required,autocomplete"`
+type optionTag struct {
+	name         string
+	required     bool
+	autocomplete bool
+}
+
+// parseOptionTag reads the option tag; fields without the tag are skipped
+func parseOptionTag(field reflect.StructField) (optionTag, bool) {
+	raw, ok := field.Tag.Lookup("option")
+	if !ok || raw == "-" || !field.IsExported() {
+		return optionTag{}, false
+	}
+
+	parts := strings.Split(raw, ",")
+	tag := optionTag{name: parts[0]}
+	if tag.name == "" {
+		tag.name = strings.ToLower(field.Name)
+	}
+	for _, flag := range parts[1:] {
+		switch flag {
+		case "required":
+			tag.required = true
+		case "autocomplete":
+			tag.autocomplete = true
+		}
+	}
+	return tag, true
+}
+
+func buildOption(field reflect.StructField, tag optionTag) (*discordgo.ApplicationCommandOption, error) {
+	name := tag.name
+
+	optionType, err := optionTypeOf(field.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	description := field.Tag.Get("description")
+	if description == "" {
+		description = name
+	}
+
+	opt := &discordgo.ApplicationCommandOption{
+		Type:         optionType,
+		Name:         name,
+		Description:  description,
+		Required:     tag.required,
+		Autocomplete: tag.autocomplete,
+	}
+
+	if v, ok := field.Tag.Lookup("min"); ok {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid min %q", v)
+		}
+		opt.MinValue = &f
+	}
+	if v, ok := field.Tag.Lookup("max"); ok {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max %q", v)
+		}
+		if f == 0 {
+			// MaxValue is sent with omitempty, so 0 would silently mean "no maximum"
+			return nil, fmt.Errorf("max %q is not supported by discordgo, check the upper bound in the handler instead", v)
+		}
+		opt.MaxValue = f
+	}
+	if v, ok := field.Tag.Lookup("minlen"); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid minlen %q", v)
+		}
+		opt.MinLength = &n
+	}
+	if v, ok := field.Tag.Lookup("maxlen"); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maxlen %q", v)
+		}
+		if n <= 0 {
+			return nil, fmt.Errorf("maxlen %q must be at least 1", v)
+		}
+		opt.MaxLength = n
+	}
+
+	if v, ok := field.Tag.Lookup("choices"); ok {
+		choices, err := parseChoices(v, optionType)
+		if err != nil {
+			return nil, err
+		}
+		opt.Choices = choices
+	}
+
+	if v, ok := field.Tag.Lookup("channel"); ok {
+		for _, name := range strings.Split(v, ",") {
+			ct, ok := channelTypeNames[strings.TrimSpace(name)]
+			if !ok {
+				return nil, fmt.Errorf("unknown channel type %q", name)
+			}
+			opt.ChannelTypes = append(opt.ChannelTypes, ct)
+		}
+	}
+
+	return opt, nil
+}
+
+// optionTypeOf maps a Go field type to a Discord option type
+func optionTypeOf(t reflect.Type) (discordgo.ApplicationCommandOptionType, error) {
+	switch t {
+	case userType, memberType:
+		return discordgo.ApplicationCommandOptionUser, nil
+	case roleType:
+		return discordgo.ApplicationCommandOptionRole, nil
+	case channelType:
+		return discordgo.ApplicationCommandOptionChannel, nil
+	case attachmentType:
+		return discordgo.ApplicationCommandOptionAttachment, nil
+	}
+
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return discordgo.ApplicationCommandOptionString, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return discordgo.ApplicationCommandOptionInteger, nil
+	case reflect.Float32, reflect.Float64:
+		return discordgo.ApplicationCommandOptionNumber, nil
+	case reflect.Bool:
+		return discordgo.ApplicationCommandOptionBoolean, nil
+	}
+
+	return 0, fmt.Errorf("unsupported option type %s", t)
+}
+
+// parseChoices parses "Label=value,Label2=value2" into typed choices
+func parseChoices(tag string, optionType discordgo.ApplicationCommandOptionType) ([]*discordgo.ApplicationCommandOptionChoice, error) {
+	var choices []*discordgo.ApplicationCommandOptionChoice
+
+	for _, item := range strings.Split(tag, ",") {
+		label, raw, found := strings.Cut(item, "=")
+		if !found {
+			raw = label
+		}
+
+		var value interface{} = raw
+		switch optionType {
+		case discordgo.ApplicationCommandOptionInteger:
+			n, err := strconv.ParseInt(raw, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid integer choice %q", raw)
+			}
+			value = n
+		case discordgo.ApplicationCommandOptionNumber:
+			f, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number choice %q", raw)
+			}
+			value = f
+		case discordgo.ApplicationCommandOptionString:
+		default:
+			return nil, fmt.Errorf("choices are only supported on string, integer and number options")
+		}
+
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  label,
+			Value: value,
+		})
+	}
+
+	return choices, nil
+}
+
+// ============================================
+// Decoding
+// ============================================
+
+// DecodeOptions fills the struct pointed to by dst from interaction options.
+// Users, members, roles, channels and attachments are looked up in resolved.
+// Problems with the submitted options are reported as i18n errors (see Translator.Error).
+func DecodeOptions(options []*discordgo.ApplicationCommandInteractionDataOption, resolved *discordgo.ApplicationCommandInteractionDataResolved, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("destination must be a pointer to a struct")
+	}
+	v = v.Elem()
+
+	byName := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, opt := range options {
+		byName[opt.Name] = opt
+	}
+
+	if resolved == nil {
+		resolved = &discordgo.ApplicationCommandInteractionDataResolved{}
+	}
+
+	for _, field := range reflect.VisibleFields(v.Type()) {
+		tag, ok := parseOptionTag(field)
+		if !ok {
+			continue
+		}
+
+		opt, ok := byName[tag.name]
+		if !ok {
+			if tag.required {
+				return i18n.Errorf("validation.required", tag.name)
+			}
+			continue
+		}
+
+		if err := decodeOption(v.FieldByIndex(field.Index), opt, resolved); err != nil {
+			return i18n.Errorf("validation.field", tag.name, err)
+		}
+	}
+
+	return nil
+}
+
+func decodeOption(fv reflect.Value, opt *discordgo.ApplicationCommandInteractionDataOption, resolved *discordgo.ApplicationCommandInteractionDataResolved) error {
+	id, _ := opt.Value.(string)
+
+	switch fv.Type() {
+	case userType:
+		user, ok := resolved.Users[id]
+		if !ok {
+			user = &discordgo.User{ID: id}
+		}
+		fv.Set(reflect.ValueOf(user))
+		return nil
+
+	case memberType:
+		member, ok := resolved.Members[id]
+		if !ok {
+			return i18n.Errorf("validation.member")
+		}
+		// Resolved members don't include the user object
+		m := *member
+		if user, ok := resolved.Users[id]; ok {
+			m.User = user
+		}
+		fv.Set(reflect.ValueOf(&m))
+		return nil
+
+	case roleType:
+		role, ok := resolved.Roles[id]
+		if !ok {
+			return i18n.Errorf("validation.unresolved")
+		}
+		fv.Set(reflect.ValueOf(role))
+		return nil
+
+	case channelType:
+		channel, ok := resolved.Channels[id]
+		if !ok {
+			return i18n.Errorf("validation.unresolved")
+		}
+		fv.Set(reflect.ValueOf(channel))
+		return nil
+
+	case attachmentType:
+		attachment, ok := resolved.Attachments[id]
+		if !ok {
+			return i18n.Errorf("validation.unresolved")
+		}
+		fv.Set(reflect.ValueOf(attachment))
+		return nil
+	}
+
+	// Optional scalars: allocate the pointer so the handler can tell "not provided" from zero
+	if fv.Kind() == reflect.Pointer {
+		fv.Set(reflect.New(fv.Type().Elem()))
+		fv = fv.Elem()
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(opt.StringValue())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fv.SetInt(opt.IntValue())
+	case reflect.Float32, reflect.Float64:
+		fv.SetFloat(opt.FloatValue())
+	case reflect.Bool:
+		fv.SetBool(opt.BoolValue())
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+
+	return nil
+}
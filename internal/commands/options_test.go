@@ -0,0 +1,232 @@
+package commands
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"discord-bot-template/internal/i18n"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+type testOptions struct {
+	Reason   string             `option:"reason" description:"Why" minlen:"3" maxlen:"100"`
+	Count    int                `option:"count,required" min:"1" max:"10"`
+	Ratio    *float64           `option:"ratio" min:"-1.5"`
+	Color    string             `option:"color" choices:"Red=red,Blue=blue"`
+	Size     int                `option:"size" choices:"Small=1,Large=3"`
+	Silent   bool               `option:"silent"`
+	User     *discordgo.User    `option:"user,required"`
+	Member   *discordgo.Member  `option:"member"`
+	Role     *discordgo.Role    `option:"role"`
+	Channel  *discordgo.Channel `option:"channel" channel:"text,voice"`
+	Query    string             `option:"query,autocomplete"`
+	Internal string
+	Skipped  string `option:"-"`
+}
+
+func TestOptionsFor(t *testing.T) {
+	options, err := OptionsFor[testOptions]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byName := make(map[string]*discordgo.ApplicationCommandOption)
+	var names []string
+	for _, opt := range options {
+		byName[opt.Name] = opt
+		names = append(names, opt.Name)
+	}
+
+	// Required options first, otherwise in field order; untagged and "-" fields are skipped
+	wantNames := []string{"count", "user", "reason", "ratio", "color", "size", "silent", "member", "role", "channel", "query"}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Fatalf("options = %v, want %v", names, wantNames)
+	}
+
+	types := map[string]discordgo.ApplicationCommandOptionType{
+		"reason":  discordgo.ApplicationCommandOptionString,
+		"count":   discordgo.ApplicationCommandOptionInteger,
+		"ratio":   discordgo.ApplicationCommandOptionNumber,
+		"silent":  discordgo.ApplicationCommandOptionBoolean,
+		"user":    discordgo.ApplicationCommandOptionUser,
+		"member":  discordgo.ApplicationCommandOptionUser,
+		"role":    discordgo.ApplicationCommandOptionRole,
+		"channel": discordgo.ApplicationCommandOptionChannel,
+	}
+	for name, want := range types {
+		if got := byName[name].Type; got != want {
+			t.Errorf("%s type = %v, want %v", name, got, want)
+		}
+	}
+
+	count := byName["count"]
+	if !count.Required || count.MinValue == nil || *count.MinValue != 1 || count.MaxValue != 10 {
+		t.Errorf("count = required %v, min %v, max %v", count.Required, count.MinValue, count.MaxValue)
+	}
+	if ratio := byName["ratio"]; ratio.Required || ratio.MinValue == nil || *ratio.MinValue != -1.5 {
+		t.Errorf("ratio = required %v, min %v", ratio.Required, ratio.MinValue)
+	}
+	reason := byName["reason"]
+	if reason.Description != "Why" || reason.MinLength == nil || *reason.MinLength != 3 || reason.MaxLength != 100 {
+		t.Errorf("reason = %q, minlen %v, maxlen %d", reason.Description, reason.MinLength, reason.MaxLength)
+	}
+	if byName["silent"].Description != "silent" {
+		t.Errorf("description defaults to %q, want the option name", byName["silent"].Description)
+	}
+	if !byName["query"].Autocomplete {
+		t.Errorf("query is not marked autocomplete")
+	}
+
+	wantColors := []*discordgo.ApplicationCommandOptionChoice{{Name: "Red", Value: "red"}, {Name: "Blue", Value: "blue"}}
+	if got := byName["color"].Choices; !reflect.DeepEqual(got, wantColors) {
+		t.Errorf("color choices = %v", got)
+	}
+	wantSizes := []*discordgo.ApplicationCommandOptionChoice{{Name: "Small", Value: int64(1)}, {Name: "Large", Value: int64(3)}}
+	if got := byName["size"].Choices; !reflect.DeepEqual(got, wantSizes) {
+		t.Errorf("size choices = %v", got)
+	}
+	wantChannels := []discordgo.ChannelType{discordgo.ChannelTypeGuildText, discordgo.ChannelTypeGuildVoice}
+	if got := byName["channel"].ChannelTypes; !reflect.DeepEqual(got, wantChannels) {
+		t.Errorf("channel types = %v", got)
+	}
+}
+
+func TestOptionsForInvalidTags(t *testing.T) {
+	tests := []struct {
+		name string
+		gen  func() error
+	}{
+		{"invalid min", func() error {
+			_, err := OptionsFor[struct {
+				N int `option:"n" min:"one"`
+			}]()
+			return err
+		}},
+		{"zero max", func() error {
+			_, err := OptionsFor[struct {
+				N int `option:"n" max:"0"`
+			}]()
+			return err
+		}},
+		{"zero maxlen", func() error {
+			_, err := OptionsFor[struct {
+				S string `option:"s" maxlen:"0"`
+			}]()
+			return err
+		}},
+		{"invalid integer choice", func() error {
+			_, err := OptionsFor[struct {
+				N int `option:"n" choices:"One=one"`
+			}]()
+			return err
+		}},
+		{"choices on a boolean", func() error {
+			_, err := OptionsFor[struct {
+				B bool `option:"b" choices:"Yes=true"`
+			}]()
+			return err
+		}},
+		{"unknown channel type", func() error {
+			_, err := OptionsFor[struct {
+				C *discordgo.Channel `option:"c" channel:"kitchen"`
+			}]()
+			return err
+		}},
+		{"unsupported type", func() error {
+			_, err := OptionsFor[struct {
+				U uint `option:"u"`
+			}]()
+			return err
+		}},
+		{"not a struct", func() error {
+			_, err := OptionsFor[string]()
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.gen(); err == nil {
+				t.Errorf("OptionsFor accepted the tags")
+			}
+		})
+	}
+}
+
+func TestDecodeOptions(t *testing.T) {
+	resolved := &discordgo.ApplicationCommandInteractionDataResolved{
+		Users: map[string]*discordgo.User{
+			"1": {ID: "1", Username: "alice"},
+			"2": {ID: "2", Username: "bob"},
+		},
+		Members:  map[string]*discordgo.Member{"2": {Nick: "Bobby"}},
+		Roles:    map[string]*discordgo.Role{"3": {ID: "3", Name: "Staff"}},
+		Channels: map[string]*discordgo.Channel{"4": {ID: "4", Name: "general"}},
+	}
+	option := func(name string, kind discordgo.ApplicationCommandOptionType, value interface{}) *discordgo.ApplicationCommandInteractionDataOption {
+		return &discordgo.ApplicationCommandInteractionDataOption{Name: name, Type: kind, Value: value}
+	}
+
+	var got testOptions
+	err := DecodeOptions([]*discordgo.ApplicationCommandInteractionDataOption{
+		option("reason", discordgo.ApplicationCommandOptionString, "spam"),
+		option("count", discordgo.ApplicationCommandOptionInteger, float64(3)), // JSON numbers
+		option("ratio", discordgo.ApplicationCommandOptionNumber, 0.0),
+		option("silent", discordgo.ApplicationCommandOptionBoolean, true),
+		option("user", discordgo.ApplicationCommandOptionUser, "1"),
+		option("member", discordgo.ApplicationCommandOptionUser, "2"),
+		option("role", discordgo.ApplicationCommandOptionRole, "3"),
+		option("channel", discordgo.ApplicationCommandOptionChannel, "4"),
+	}, resolved, &got)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Reason != "spam" || got.Count != 3 || !got.Silent {
+		t.Errorf("scalars = %q, %d, %v", got.Reason, got.Count, got.Silent)
+	}
+	if got.Ratio == nil || *got.Ratio != 0 {
+		t.Errorf("ratio = %v, want a pointer to 0 (provided, not missing)", got.Ratio)
+	}
+	if got.User.Username != "alice" || got.Role.Name != "Staff" || got.Channel.Name != "general" {
+		t.Errorf("resolved = %q, %q, %q", got.User.Username, got.Role.Name, got.Channel.Name)
+	}
+	if got.Member.Nick != "Bobby" || got.Member.User == nil || got.Member.User.Username != "bob" {
+		t.Errorf("member = %+v, want the resolved member with its user", got.Member)
+	}
+	if got.Color != "" || got.Query != "" {
+		t.Errorf("options that weren't sent = %q, %q, want zero values", got.Color, got.Query)
+	}
+}
+
+func TestDecodeOptionsErrors(t *testing.T) {
+	user := &discordgo.ApplicationCommandInteractionDataOption{Name: "user", Type: discordgo.ApplicationCommandOptionUser, Value: "1"}
+	count := &discordgo.ApplicationCommandInteractionDataOption{Name: "count", Type: discordgo.ApplicationCommandOptionInteger, Value: float64(1)}
+	member := &discordgo.ApplicationCommandInteractionDataOption{Name: "member", Type: discordgo.ApplicationCommandOptionUser, Value: "9"}
+
+	tests := []struct {
+		name    string
+		options []*discordgo.ApplicationCommandInteractionDataOption
+		want    string // Message in en-US
+	}{
+		{"missing required", []*discordgo.ApplicationCommandInteractionDataOption{user}, "count is required"},
+		{"member not in guild", []*discordgo.ApplicationCommandInteractionDataOption{user, count, member}, "member must be a member of this server"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got testOptions
+			err := DecodeOptions(tt.options, nil, &got)
+
+			var localized *i18n.Error
+			if !errors.As(err, &localized) {
+				t.Fatalf("DecodeOptions = %v, want an i18n error", err)
+			}
+			if msg := i18n.New(discordgo.EnglishUS).Error(err); msg != tt.want {
+				t.Errorf("message = %q, want %q", msg, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"discord-bot-template/internal/auth"
+	"discord-bot-template/internal/embed"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func init() {
+	// /permissions grant | revoke | list
+	RegisterCommand(permissionsCommand, nil, WithPermission(auth.PermissionServerAdmin))
+
+	RegisterTypedSubcommand("permissions grant", &discordgo.ApplicationCommandOption{
+		Description: "Grant a bot permission level to a role or user",
+	}, PermissionsGrantHandler, WithPermission(auth.PermissionServerAdmin))
+
+	RegisterTypedSubcommand("permissions revoke", &discordgo.ApplicationCommandOption{
+		Description: "Remove a role or user's bot permission grant",
+	}, PermissionsRevokeHandler, WithPermission(auth.PermissionServerAdmin))
+
+	RegisterSubcommand("permissions list", &discordgo.ApplicationCommandOption{
+		Description: "List bot permission grants in this server",
+	}, PermissionsListHandler, WithPermission(auth.PermissionServerAdmin))
+}
+
+var permissionsDMAllowed = false
+
+var permissionsCommand = &discordgo.ApplicationCommand{
+	Name:         "permissions",
+	Description:  "Manage bot permission grants for this server",
+	DMPermission: &permissionsDMAllowed,
+}
+
+// PermissionTarget selects exactly one role or user
+type PermissionTarget struct {
+	Role *discordgo.Role `option:"role" description:"Role to update"`
+	User *discordgo.User `option:"user" description:"User to update"`
+}
+
+// PermissionsGrantOptions are the options of /permissions grant
+type PermissionsGrantOptions struct {
+	Level string `option:"level,required" description:"Permission level" choices:"Server Admin=server_admin,Bot Moderator=bot_moderator"`
+	PermissionTarget
+}
+
+// ============================================
+// Handlers
+// ============================================
+
+func PermissionsGrantHandler(ctx *Context, opts *PermissionsGrantOptions) error {
+	level, err := auth.ParsePermission(opts.Level)
+	if err != nil || !isGrantable(level) {
+		return &UserError{Message: ctx.T("permissions.not_grantable", opts.Level)}
+	}
+	if !canManageGrant(ctx, level, false) {
+		return &UserError{Message: ctx.T("permissions.above_own_level", embed.Bold(PermissionName(ctx.Translator(), ctx.Permission())))}
+	}
+
+	target, err := permissionTarget(ctx, opts.PermissionTarget)
+	if err != nil {
+		return err
+	}
+
+	if opts.Role != nil {
+		err = auth.Grants().GrantRole(ctx.GuildID(), opts.Role.ID, level)
+	} else {
+		err = auth.Grants().GrantUser(ctx.GuildID(), opts.User.ID, level)
+	}
+	if err != nil {
+		return err
+	}
+
+	e := embed.Success(ctx.T("permissions.granted.title"),
+		ctx.T("permissions.granted.message", target, embed.Bold(PermissionName(ctx.Translator(), level))))
+	return ctx.ReplyEmbeds([]*discordgo.MessageEmbed{e}, true)
+}
+
+func PermissionsRevokeHandler(ctx *Context, opts *PermissionTarget) error {
+	target, err := permissionTarget(ctx, *opts)
+	if err != nil {
+		return err
+	}
+
+	targetID := ""
+	if opts.Role != nil {
+		targetID = opts.Role.ID
+	} else {
+		targetID = opts.User.ID
+	}
+
+	if level, ok := auth.Grants().Get(ctx.GuildID(), targetID); ok && !canManageGrant(ctx, level, true) {
+		return &UserError{Message: ctx.T("permissions.revoke_not_allowed", embed.Bold(PermissionName(ctx.Translator(), level)))}
+	}
+
+	removed, err := auth.Grants().Revoke(ctx.GuildID(), targetID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return &UserError{Message: ctx.T("permissions.no_grant", target)}
+	}
+
+	e := embed.Success(ctx.T("permissions.revoked.title"), ctx.T("permissions.revoked.message", target))
+	return ctx.ReplyEmbeds([]*discordgo.MessageEmbed{e}, true)
+}
+
+func PermissionsListHandler(ctx *Context) error {
+	grants := auth.Grants().List(ctx.GuildID())
+
+	if len(grants.Roles) == 0 && len(grants.Users) == 0 {
+		e := embed.Info(ctx.T("permissions.list.title"), ctx.T("permissions.list.empty"))
+		return ctx.ReplyEmbeds([]*discordgo.MessageEmbed{e}, true)
+	}
+
+	e := embed.New().
+		Title(ctx.T("permissions.list.title")).
+		FooterText(ctx.Plural("permissions.list.count", len(grants.Roles)+len(grants.Users))).
+		Color(embed.ColorInfo)
+	if len(grants.Roles) > 0 {
+		e.BlockField(ctx.T("permissions.list.roles"), formatGrants(ctx, grants.Roles, embed.MentionRole))
+	}
+	if len(grants.Users) > 0 {
+		e.BlockField(ctx.T("permissions.list.users"), formatGrants(ctx, grants.Users, embed.Mention))
+	}
+
+	return ctx.ReplyEphemeral(e)
+}
+
+// ============================================
+// Helpers
+// ============================================
+
+// permissionTarget validates that exactly one of role/user was given and returns its mention
+func permissionTarget(ctx *Context, t PermissionTarget) (string, error) {
+	switch {
+	case t.Role != nil && t.User != nil:
+		return "", &UserError{Message: ctx.T("permissions.choose_one")}
+	case t.Role != nil:
+		return embed.MentionRole(t.Role.ID), nil
+	case t.User != nil:
+		return embed.Mention(t.User.ID), nil
+	}
+	return "", &UserError{Message: ctx.T("permissions.choose_target")}
+}
+
+// canManageGrant reports whether the caller may grant (or revoke) a level. Guild owners,
+// Administrators and bot admins manage every grantable level. Members whose level comes
+// from a grant can't hand out more than they have (a granted Server Admin can't make
+// themselves Bot Moderator) and only take away grants below their own level.
+func canManageGrant(ctx *Context, level auth.Permission, revoke bool) bool {
+	if ctx.Permission() >= auth.PermissionBotAdmin || auth.IsGuildAdmin(ctx.Session, ctx.Interaction) {
+		return true
+	}
+	if revoke {
+		return level < ctx.Permission()
+	}
+	return level <= ctx.Permission()
+}
+
+func isGrantable(level auth.Permission) bool {
+	for _, l := range auth.GrantableLevels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
+
+// formatGrants renders "@target — Level" lines sorted by ID
+func formatGrants(ctx *Context, grants map[string]auth.Permission, mention func(string) string) string {
+	ids := make([]string, 0, len(grants))
+	for id := range grants {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	lines := make([]string, len(ids))
+	for idx, id := range ids {
+		lines[idx] = fmt.Sprintf("%s — %s", mention(id), PermissionName(ctx.Translator(), grants[id]))
+	}
+	return strings.Join(lines, "\n")
+}
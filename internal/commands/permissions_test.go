@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"discord-bot-template/internal/auth"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// roundTripFunc fakes Discord's API for handler tests
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// newTestSession returns a session whose API calls all succeed without leaving the process
+func newTestSession(t *testing.T) *discordgo.Session {
+	t.Helper()
+	s, err := discordgo.New("Bot test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Client = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader("{}")),
+			Request:    r,
+		}, nil
+	})}
+	return s
+}
+
+// newTestContext creates a slash command context for a guild member
+func newTestContext(t *testing.T, guildID string, member *discordgo.Member) *Context {
+	t.Helper()
+	i := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		ID:      "interaction",
+		AppID:   "app",
+		Token:   "token",
+		Type:    discordgo.InteractionApplicationCommand,
+		GuildID: guildID,
+		Member:  member,
+	}}
+	ctx, cancel := NewContext(context.Background(), newTestSession(t), i)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestPermissionsGrantAndRevoke(t *testing.T) {
+	administrator := func() *discordgo.Member {
+		return &discordgo.Member{User: &discordgo.User{ID: "admin"}, Permissions: discordgo.PermissionAdministrator}
+	}
+	// Granted Server Admin through a role, without Discord's Administrator permission
+	grantedAdmin := func() *discordgo.Member {
+		return &discordgo.Member{
+			User:        &discordgo.User{ID: "staff"},
+			Roles:       []string{"staff_role"},
+			Permissions: discordgo.PermissionSendMessages,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		member   func() *discordgo.Member
+		existing auth.Permission // Grant the target already has (PermissionNone: none)
+		revoke   bool
+		level    string
+		allowed  bool
+	}{
+		{name: "administrator grants bot moderator", member: administrator, level: "bot_moderator", allowed: true},
+		{name: "administrator grants server admin", member: administrator, level: "server_admin", allowed: true},
+		{name: "administrator revokes bot moderator", member: administrator, existing: auth.PermissionBotModerator, revoke: true, allowed: true},
+		{name: "granted admin grants server admin", member: grantedAdmin, level: "server_admin", allowed: true},
+		{name: "granted admin can't grant bot moderator", member: grantedAdmin, level: "bot_moderator"},
+		{name: "granted admin can't revoke server admin", member: grantedAdmin, existing: auth.PermissionServerAdmin, revoke: true},
+		{name: "granted admin can't revoke bot moderator", member: grantedAdmin, existing: auth.PermissionBotModerator, revoke: true},
+	}
+
+	for idx, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guildID := "guild" + string(rune('a'+idx))
+			if err := auth.Grants().GrantRole(guildID, "staff_role", auth.PermissionServerAdmin); err != nil {
+				t.Fatal(err)
+			}
+			if tt.existing != auth.PermissionNone {
+				if err := auth.Grants().GrantRole(guildID, "target", tt.existing); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			ctx := newTestContext(t, guildID, tt.member())
+			target := PermissionTarget{Role: &discordgo.Role{ID: "target"}}
+			var err error
+			if tt.revoke {
+				err = PermissionsRevokeHandler(ctx, &target)
+			} else {
+				err = PermissionsGrantHandler(ctx, &PermissionsGrantOptions{Level: tt.level, PermissionTarget: target})
+			}
+
+			var userErr *UserError
+			if tt.allowed && err != nil {
+				t.Fatalf("handler returned %v, want success", err)
+			}
+			if !tt.allowed && !errors.As(err, &userErr) {
+				t.Fatalf("handler returned %v, want a UserError", err)
+			}
+
+			level, granted := auth.Grants().Get(guildID, "target")
+			switch {
+			case tt.revoke && granted != !tt.allowed:
+				t.Errorf("target still granted = %v after revoke (allowed = %v)", granted, tt.allowed)
+			case !tt.revoke && tt.allowed && !granted:
+				t.Errorf("target was not granted %s", tt.level)
+			case !tt.revoke && !tt.allowed && granted:
+				t.Errorf("target was granted %s", level)
+			}
+		})
+	}
+}
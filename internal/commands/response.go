@@ -0,0 +1,316 @@
+package commands
+
+import (
+	"errors"
+	"log"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ErrAlreadyAcknowledged is returned when a response type can only be sent as the first response
+// (e.g. opening a modal after the interaction was deferred)
+var ErrAlreadyAcknowledged = errors.New("interaction already acknowledged")
+
+type responseState int
+
+const (
+	statePending  responseState = iota // Nothing sent yet
+	stateDeferred                      // Deferred, waiting for Edit
+	stateAnswered                      // Initial response sent
+)
+
+// Response tracks the state of an interaction's response so handlers can
+// defer, edit and send follow-ups without caring what was already sent.
+// It is embedded in Context, so handlers call these methods on ctx directly.
+//
+//	ctx.Defer(true)                       // "Bot is thinking..." (ephemeral)
+//	// ... slow work ...
+//	ctx.Reply(&discordgo.InteractionResponseData{Content: "Done!"}) // edits the deferred response
+//	ctx.Followup(&discordgo.WebhookParams{Content: "One more thing"})
+type Response struct {
+	session     *discordgo.Session
+	interaction *discordgo.Interaction
+
+	mu        sync.Mutex
+	state     responseState
+	ephemeral bool // Whether the deferred response is ephemeral
+	updating  bool // Deferred with DeferUpdate: @original is the component's message
+}
+
+// NewResponse creates a response helper for an interaction
+func NewResponse(s *discordgo.Session, i *discordgo.InteractionCreate) *Response {
+	return &Response{
+		session:     s,
+		interaction: i.Interaction,
+	}
+}
+
+// ============================================
+// Allowed Mentions
+// ============================================
+
+// Mention policies for DefaultAllowedMentions (or a message's own AllowedMentions)
+var (
+	// AllowNoMentions renders mentions without pinging anyone
+	AllowNoMentions = &discordgo.MessageAllowedMentions{Parse: []discordgo.AllowedMentionType{}}
+	// AllowUserMentions pings mentioned users, but not roles, @everyone or @here
+	AllowUserMentions = &discordgo.MessageAllowedMentions{Parse: []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeUsers}}
+	// AllowAllMentions pings everything Discord would by default
+	AllowAllMentions = &discordgo.MessageAllowedMentions{Parse: []discordgo.AllowedMentionType{
+		discordgo.AllowedMentionTypeUsers,
+		discordgo.AllowedMentionTypeRoles,
+		discordgo.AllowedMentionTypeEveryone,
+	}}
+)
+
+// DefaultAllowedMentions applies to every message sent through Response (Reply, Update,
+// Edit, Followup, ...) that doesn't set its own AllowedMentions. It only affects the
+// message content: mentions in embeds never ping.
+//
+//	ctx.Reply(&discordgo.InteractionResponseData{
+//	    Content:         "@everyone the server restarts in 5 minutes",
+//	    AllowedMentions: commands.AllowAllMentions,
+//	})
+var DefaultAllowedMentions = AllowUserMentions
+
+// ============================================
+// Initial Responses
+// ============================================
+
+// Respond sends a raw interaction response.
+// Message responses sent after the interaction was already acknowledged are
+// delivered with Edit / Followup instead (see Reply).
+func (r *Response) Respond(resp *discordgo.InteractionResponse) error {
+	_, err := r.respond(resp)
+	return err
+}
+
+// respond sends a response and returns the ID of the follow-up message it became ("" if none)
+func (r *Response) respond(resp *discordgo.InteractionResponse) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	isMessage := resp.Type == discordgo.InteractionResponseChannelMessageWithSource ||
+		resp.Type == discordgo.InteractionResponseUpdateMessage
+	if isMessage && resp.Data != nil && resp.Data.AllowedMentions == nil {
+		data := *resp.Data
+		data.AllowedMentions = DefaultAllowedMentions
+		resp = &discordgo.InteractionResponse{Type: resp.Type, Data: &data}
+	}
+
+	if r.state == statePending {
+		if err := r.session.InteractionRespond(r.interaction, resp); err != nil {
+			return "", err
+		}
+		r.state = stateAnswered
+		switch resp.Type {
+		case discordgo.InteractionResponseDeferredChannelMessageWithSource,
+			discordgo.InteractionResponseDeferredMessageUpdate:
+			r.state = stateDeferred
+			r.ephemeral = resp.Data != nil && resp.Data.Flags&discordgo.MessageFlagsEphemeral != 0
+			r.updating = resp.Type == discordgo.InteractionResponseDeferredMessageUpdate
+		}
+		return "", nil
+	}
+
+	switch resp.Type {
+	case discordgo.InteractionResponseChannelMessageWithSource,
+		discordgo.InteractionResponseUpdateMessage:
+		return r.deliverLocked(resp.Type, resp.Data)
+	}
+	return "", ErrAlreadyAcknowledged
+}
+
+// Reply sends a message. Depending on what was sent before, this is the initial
+// response, an edit of the deferred response, or a follow-up message.
+func (r *Response) Reply(data *discordgo.InteractionResponseData) error {
+	return r.Respond(&discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: data,
+	})
+}
+
+// ReplyMessage is Reply for messages that are edited later (e.g. to disable their buttons).
+// It returns the ID of the follow-up message the reply became, or "" if it is the
+// original response; pass it to EditMessage.
+func (r *Response) ReplyMessage(data *discordgo.InteractionResponseData) (messageID string, err error) {
+	return r.respond(&discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: data,
+	})
+}
+
+// Update edits the message a component is attached to (components only)
+func (r *Response) Update(data *discordgo.InteractionResponseData) error {
+	return r.Respond(&discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: data,
+	})
+}
+
+// Defer acknowledges the interaction and shows "Bot is thinking...".
+// The message is filled in later with Reply or Edit (within 15 minutes).
+func (r *Response) Defer(ephemeral bool) error {
+	var flags discordgo.MessageFlags
+	if ephemeral {
+		flags = discordgo.MessageFlagsEphemeral
+	}
+	return r.Respond(&discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Flags: flags},
+	})
+}
+
+// DeferUpdate acknowledges a component interaction without changing the message yet
+func (r *Response) DeferUpdate() error {
+	return r.Respond(&discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	})
+}
+
+// ============================================
+// After Responding
+// ============================================
+
+// Edit edits the original response (or the deferred "thinking" message)
+func (r *Response) Edit(edit *discordgo.WebhookEdit) (*discordgo.Message, error) {
+	if edit.AllowedMentions == nil {
+		e := *edit
+		e.AllowedMentions = DefaultAllowedMentions
+		edit = &e
+	}
+	msg, err := r.session.InteractionResponseEdit(r.interaction, edit)
+	if err == nil {
+		r.mu.Lock()
+		r.state = stateAnswered
+		r.mu.Unlock()
+	}
+	return msg, err
+}
+
+// EditMessage edits a message sent with ReplyMessage ("" is the original response)
+func (r *Response) EditMessage(messageID string, edit *discordgo.WebhookEdit) (*discordgo.Message, error) {
+	if messageID == "" {
+		return r.Edit(edit)
+	}
+	if edit.AllowedMentions == nil {
+		e := *edit
+		e.AllowedMentions = DefaultAllowedMentions
+		edit = &e
+	}
+	return r.session.FollowupMessageEdit(r.interaction, messageID, edit)
+}
+
+// Followup sends an additional message
+func (r *Response) Followup(params *discordgo.WebhookParams) (*discordgo.Message, error) {
+	if params.AllowedMentions == nil {
+		p := *params
+		p.AllowedMentions = DefaultAllowedMentions
+		params = &p
+	}
+	return r.session.FollowupMessageCreate(r.interaction, true, params)
+}
+
+// Delete deletes the original response
+func (r *Response) Delete() error {
+	return r.session.InteractionResponseDelete(r.interaction)
+}
+
+// Acknowledged reports whether anything (response or deferral) was sent
+func (r *Response) Acknowledged() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.state != statePending
+}
+
+// deliverLocked sends message data after the interaction was acknowledged (caller holds the lock).
+// It returns the ID of the follow-up message, or "" when the original response was edited.
+func (r *Response) deliverLocked(responseType discordgo.InteractionResponseType, data *discordgo.InteractionResponseData) (string, error) {
+	if data == nil {
+		data = &discordgo.InteractionResponseData{}
+	}
+	ephemeral := data.Flags&discordgo.MessageFlagsEphemeral != 0
+
+	// Deferred update: @original is the message the component is attached to.
+	// Only Update edits it, replies become follow-ups.
+	if r.state == stateDeferred && r.updating {
+		r.state = stateAnswered
+	}
+
+	// Deferred "thinking" message: fill it in, unless that would make an
+	// ephemeral reply public (ephemerality is fixed when deferring)
+	if r.state == stateDeferred && (!ephemeral || r.ephemeral || responseType == discordgo.InteractionResponseUpdateMessage) {
+		_, err := r.session.InteractionResponseEdit(r.interaction, webhookEdit(data))
+		if err == nil {
+			r.state = stateAnswered
+		}
+		return "", err
+	}
+
+	if r.state == stateDeferred {
+		// Public deferral but private reply: drop the placeholder and send privately
+		if err := r.session.InteractionResponseDelete(r.interaction); err != nil {
+			log.Printf("Failed to delete deferred response: %v", err)
+		}
+		r.state = stateAnswered
+	}
+
+	if responseType == discordgo.InteractionResponseUpdateMessage {
+		_, err := r.session.InteractionResponseEdit(r.interaction, webhookEdit(data))
+		return "", err
+	}
+
+	msg, err := r.session.FollowupMessageCreate(r.interaction, true, &discordgo.WebhookParams{
+		Content:         data.Content,
+		Embeds:          data.Embeds,
+		Components:      data.Components,
+		Files:           data.Files,
+		AllowedMentions: data.AllowedMentions,
+		Flags:           data.Flags,
+	})
+	if err != nil {
+		return "", err
+	}
+	return msg.ID, nil
+}
+
+// webhookEdit converts response data into an edit payload
+func webhookEdit(data *discordgo.InteractionResponseData) *discordgo.WebhookEdit {
+	edit := &discordgo.WebhookEdit{
+		Content:         &data.Content,
+		Embeds:          &data.Embeds,
+		Files:           data.Files,
+		AllowedMentions: data.AllowedMentions,
+	}
+	if data.Components != nil {
+		edit.Components = &data.Components
+	}
+	return edit
+}
+
+// ============================================
+// Automatic Deferral
+// ============================================
+
+// AutoDefer defers the interaction if nothing was sent yet.
+// Components are deferred as a silent message update, everything else as "thinking".
+func (r *Response) AutoDefer() {
+	r.mu.Lock()
+	pending := r.state == statePending
+	r.mu.Unlock()
+	if !pending {
+		return
+	}
+
+	var err error
+	if r.interaction.Type == discordgo.InteractionMessageComponent {
+		err = r.DeferUpdate()
+	} else {
+		err = r.Defer(false)
+	}
+	if err != nil {
+		// Usually the handler responded directly with s.InteractionRespond
+		log.Printf("Auto-defer failed for interaction %s: %v", r.interaction.ID, err)
+	}
+}
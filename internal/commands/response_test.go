@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// recordSession returns a session that records the API calls it makes.
+// Follow-up messages are created with the ID "followup".
+func recordSession(t *testing.T) (*discordgo.Session, func() []string) {
+	t.Helper()
+	s, err := discordgo.New("Bot test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var calls []string
+	s.Client = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"id":"followup"}`)),
+			Request:    r,
+		}, nil
+	})}
+
+	return s, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), calls...)
+	}
+}
+
+func TestReplyMessageEditsTheMessageItSent(t *testing.T) {
+	data := &discordgo.InteractionResponseData{Content: "page 1"}
+	edit := &discordgo.WebhookEdit{}
+
+	tests := []struct {
+		name   string
+		before func(r *Response) error
+		wantID string
+		want   []string // The edit's API call
+	}{
+		{"initial response", func(*Response) error { return nil }, "", []string{"PATCH /api/v9/webhooks/app/token/messages/@original"}},
+		{"after defer", func(r *Response) error { return r.Defer(false) }, "", []string{"PATCH /api/v9/webhooks/app/token/messages/@original"}},
+		{"after reply", func(r *Response) error { return r.Reply(&discordgo.InteractionResponseData{Content: "hi"}) }, "followup", []string{"PATCH /api/v9/webhooks/app/token/messages/followup"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, calls := recordSession(t)
+			r := NewResponse(s, &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+				ID: "interaction", AppID: "app", Token: "token", Type: discordgo.InteractionApplicationCommand,
+			}})
+			if err := tt.before(r); err != nil {
+				t.Fatal(err)
+			}
+
+			messageID, err := r.ReplyMessage(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if messageID != tt.wantID {
+				t.Errorf("message ID = %q, want %q", messageID, tt.wantID)
+			}
+
+			sent := len(calls())
+			if _, err := r.EditMessage(messageID, edit); err != nil {
+				t.Fatal(err)
+			}
+			if got := calls()[sent:]; strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("edit called %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
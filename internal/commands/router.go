@@ -0,0 +1,160 @@
+package commands
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Params holds values captured from a custom ID pattern (e.g. "ticket_close:{id}")
+type Params map[string]string
+
+// Get returns a captured parameter (empty string if missing)
+func (p Params) Get(name string) string {
+	return p[name]
+}
+
+// ============================================
+// Router (component / modal custom ID 路由)
+// ============================================
+
+// Router matches custom IDs against exact IDs and patterns.
+//
+// Pattern syntax: "{name}" captures one segment (anything except ":").
+// Precedence is deterministic:
+//  1. Exact IDs always win over patterns
+//  2. Patterns with more literal characters win (more specific)
+//  3. Patterns with fewer parameters win
+//  4. Earlier registration wins
+type Router struct {
+	exact    map[string]*routeEntry
+	patterns []*routePattern
+}
+
+type routeEntry struct {
+	handler Handler
+	route   *Route // per-route settings (handler is bound at match time)
+}
+
+type routePattern struct {
+	*routeEntry
+	pattern  string
+	regex    *regexp.Regexp
+	names    []string
+	literals int
+	order    int
+}
+
+var placeholderRegex = regexp.MustCompile(`\{([a-zA-Z_][a-zA-Z0-9_]*)\}`)
+
+// NewRouter creates an empty router
+func NewRouter() *Router {
+	return &Router{
+		exact: make(map[string]*routeEntry),
+	}
+}
+
+// Handle registers a handler for an exact custom ID or a pattern.
+// Registering the same ID/pattern twice replaces the previous handler.
+func (r *Router) Handle(pattern string, handler Handler, opts ...RouteOption) {
+	entry := &routeEntry{
+		handler: handler,
+		route:   newRoute(nil, opts),
+	}
+
+	if !placeholderRegex.MatchString(pattern) {
+		r.exact[pattern] = entry
+		return
+	}
+
+	for _, p := range r.patterns {
+		if p.pattern == pattern {
+			p.routeEntry = entry
+			return
+		}
+	}
+
+	r.patterns = append(r.patterns, compilePattern(pattern, len(r.patterns), entry))
+	sort.SliceStable(r.patterns, func(a, b int) bool {
+		pa, pb := r.patterns[a], r.patterns[b]
+		if pa.literals != pb.literals {
+			return pa.literals > pb.literals
+		}
+		if len(pa.names) != len(pb.names) {
+			return len(pa.names) < len(pb.names)
+		}
+		return pa.order < pb.order
+	})
+}
+
+// Match finds the route for a custom ID.
+// The returned route's handler sets ctx.Params to the captured parameters.
+func (r *Router) Match(customID string) (*Route, bool) {
+	if entry, ok := r.exact[customID]; ok {
+		return entry.bind(Params{}), true
+	}
+
+	for _, p := range r.patterns {
+		matches := p.regex.FindStringSubmatch(customID)
+		if matches == nil {
+			continue
+		}
+		params := make(Params, len(p.names))
+		for idx, name := range p.names {
+			params[name] = matches[idx+1]
+		}
+		return p.bind(params), true
+	}
+
+	return nil, false
+}
+
+// bind turns the entry into a Route whose handler receives params
+func (e *routeEntry) bind(params Params) *Route {
+	route := *e.route
+	route.Handler = func(ctx *Context) error {
+		ctx.Params = params
+		return e.handler(ctx)
+	}
+	return &route
+}
+
+// compilePattern converts "ticket_close:{id}" into an anchored regular expression
+func compilePattern(pattern string, order int, entry *routeEntry) *routePattern {
+	var expr strings.Builder
+	var names []string
+	literals := 0
+	last := 0
+
+	expr.WriteString("^")
+	for _, loc := range placeholderRegex.FindAllStringSubmatchIndex(pattern, -1) {
+		literal := pattern[last:loc[0]]
+		expr.WriteString(regexp.QuoteMeta(literal))
+		literals += len(literal)
+
+		expr.WriteString("([^:]+)")
+		names = append(names, pattern[loc[2]:loc[3]])
+		last = loc[1]
+	}
+	literal := pattern[last:]
+	expr.WriteString(regexp.QuoteMeta(literal))
+	literals += len(literal)
+	expr.WriteString("$")
+
+	return &routePattern{
+		routeEntry: entry,
+		pattern:    pattern,
+		regex:      regexp.MustCompile(expr.String()),
+		names:      names,
+		literals:   literals,
+		order:      order,
+	}
+}
+
+// FormatCustomID fills a pattern's placeholders (e.g. "ticket_close:{id}" -> "ticket_close:123")
+func FormatCustomID(pattern string, params Params) string {
+	return placeholderRegex.ReplaceAllStringFunc(pattern, func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+		return params[name]
+	})
+}
@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+)
+
+// testRouter records which handler ran, so tests can see which route matched
+type testRouter struct {
+	*Router
+	ran string
+}
+
+func newTestRouter() *testRouter {
+	return &testRouter{Router: NewRouter()}
+}
+
+// handle registers pattern with a handler that records name
+func (r *testRouter) handle(pattern, name string) {
+	r.Handle(pattern, func(ctx *Context) error {
+		r.ran = name
+		return nil
+	})
+}
+
+// match runs the route matched for customID and returns the handler's name and params
+func (r *testRouter) match(t *testing.T, customID string) (string, Params) {
+	t.Helper()
+	route, ok := r.Match(customID)
+	if !ok {
+		t.Fatalf("Match(%q) found no route", customID)
+	}
+
+	r.ran = ""
+	ctx := &Context{}
+	if err := route.Handler(ctx); err != nil {
+		t.Fatalf("handler for %q: %v", customID, err)
+	}
+	return r.ran, ctx.Params
+}
+
+func TestRouterPrecedence(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns [][2]string // pattern, handler name (in registration order)
+		customID string
+		want     string
+	}{
+		{
+			name:     "exact beats pattern",
+			patterns: [][2]string{{"ticket:{id}", "pattern"}, {"ticket:new", "exact"}},
+			customID: "ticket:new",
+			want:     "exact",
+		},
+		{
+			name:     "pattern still matches other IDs",
+			patterns: [][2]string{{"ticket:{id}", "pattern"}, {"ticket:new", "exact"}},
+			customID: "ticket:42",
+			want:     "pattern",
+		},
+		{
+			name:     "more literals beat fewer",
+			patterns: [][2]string{{"{kind}:close", "fewer"}, {"ticket:{action}", "more"}},
+			customID: "ticket:close",
+			want:     "more",
+		},
+		{
+			name:     "fewer params win",
+			patterns: [][2]string{{"item_{kind}{id}", "two"}, {"item_{id}", "one"}},
+			customID: "item_42",
+			want:     "one",
+		},
+		{
+			name:     "earlier registration wins a tie",
+			patterns: [][2]string{{"{a}:shut", "first"}, {"open:{b}", "second"}},
+			customID: "open:shut",
+			want:     "first",
+		},
+		{
+			name:     "tie-break follows registration, not the pattern",
+			patterns: [][2]string{{"open:{b}", "first"}, {"{a}:shut", "second"}},
+			customID: "open:shut",
+			want:     "first",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRouter()
+			for _, p := range tt.patterns {
+				r.handle(p[0], p[1])
+			}
+			if got, _ := r.match(t, tt.customID); got != tt.want {
+				t.Errorf("Match(%q) ran %q, want %q", tt.customID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRouterParams(t *testing.T) {
+	r := newTestRouter()
+	r.handle("ticket:{action}:{id}", "ticket")
+
+	_, params := r.match(t, "ticket:close:42")
+	want := Params{"action": "close", "id": "42"}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("params = %v, want %v", params, want)
+	}
+
+	// Parameters don't span ":"
+	if _, ok := r.Match("ticket:close:42:extra"); ok {
+		t.Errorf("Match matched an ID with an extra segment")
+	}
+}
+
+func TestRouterReregister(t *testing.T) {
+	r := newTestRouter()
+	r.handle("{a}:shut", "old")
+	r.handle("open:{b}", "other")
+	r.handle("{a}:shut", "new")
+	r.handle("ticket:new", "old exact")
+	r.handle("ticket:new", "new exact")
+
+	// The replaced pattern keeps its registration order
+	if got, _ := r.match(t, "open:shut"); got != "new" {
+		t.Errorf("re-registered pattern ran %q, want %q", got, "new")
+	}
+	if got, _ := r.match(t, "ticket:new"); got != "new exact" {
+		t.Errorf("re-registered exact ID ran %q, want %q", got, "new exact")
+	}
+	if len(r.patterns) != 2 {
+		t.Errorf("router has %d patterns, want 2", len(r.patterns))
+	}
+}
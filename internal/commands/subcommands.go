@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Subcommand represents a "/command sub" or "/command group sub" handler
+type Subcommand struct {
+	Path   []string // [command, sub] or [command, group, sub]
+	Option *discordgo.ApplicationCommandOption
+	*Route
+}
+
+// ============================================
+// Auto-registration (使用 init() 自動註冊)
+// ============================================
+
+var registeredSubcommands []*Subcommand
+var groupDescriptions = make(map[string]string)
+
+// RegisterSubcommand registers a subcommand handler (call in init())
+//
+//	RegisterSubcommand("ticket open", &discordgo.ApplicationCommandOption{
+//	    Description: "Open a ticket",
+//	}, TicketOpenHandler)
+//
+// path is "command sub" or "command group sub". The root command must be
+// registered with RegisterCommand (handler can be nil).
+// option.Name and option.Type are filled in from the path. An invalid path panics.
+func RegisterSubcommand(path string, option *discordgo.ApplicationCommandOption, handler Handler, opts ...RouteOption) {
+	parts := strings.Fields(path)
+	if len(parts) < 2 || len(parts) > 3 {
+		panic(fmt.Sprintf("invalid subcommand path %q (expected \"command sub\" or \"command group sub\")", path))
+	}
+
+	opt := *option
+	opt.Name = parts[len(parts)-1]
+	opt.Type = discordgo.ApplicationCommandOptionSubCommand
+
+	registeredSubcommands = append(registeredSubcommands, &Subcommand{
+		Path:   parts,
+		Option: &opt,
+		Route:  newRoute(handler, opts),
+	})
+}
+
+// RegisterSubcommandGroup sets the description of a subcommand group (e.g. "ticket admin")
+// Groups without a description fall back to their name.
+func RegisterSubcommandGroup(path, description string) {
+	groupDescriptions[strings.Join(strings.Fields(path), " ")] = description
+}
+
+// ============================================
+// Definition Builder
+// ============================================
+
+// withSubcommands returns a copy of the definition with nested subcommand options
+func withSubcommands(definition *discordgo.ApplicationCommand) *discordgo.ApplicationCommand {
+	var options []*discordgo.ApplicationCommandOption
+	groups := make(map[string]*discordgo.ApplicationCommandOption)
+
+	for _, sub := range registeredSubcommands {
+		if sub.Path[0] != definition.Name {
+			continue
+		}
+
+		if len(sub.Path) == 2 {
+			options = append(options, sub.Option)
+			continue
+		}
+
+		groupPath := strings.Join(sub.Path[:2], " ")
+		group, ok := groups[groupPath]
+		if !ok {
+			description := groupDescriptions[groupPath]
+			if description == "" {
+				description = sub.Path[1]
+			}
+			group = &discordgo.ApplicationCommandOption{
+				Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+				Name:        sub.Path[1],
+				Description: description,
+			}
+			groups[groupPath] = group
+			options = append(options, group)
+		}
+		group.Options = append(group.Options, sub.Option)
+	}
+
+	if options == nil {
+		return definition
+	}
+
+	def := *definition
+	def.Options = options
+	return &def
+}
+
+// ============================================
+// Dispatch Helpers
+// ============================================
+
+// CommandPath returns the invoked path, e.g. "ticket", "ticket open" or "ticket admin close"
+func CommandPath(data discordgo.ApplicationCommandInteractionData) string {
+	parts := []string{data.Name}
+	options := data.Options
+
+	for len(options) > 0 {
+		opt := options[0]
+		if opt.Type != discordgo.ApplicationCommandOptionSubCommandGroup &&
+			opt.Type != discordgo.ApplicationCommandOptionSubCommand {
+			break
+		}
+		parts = append(parts, opt.Name)
+		options = opt.Options
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// SubcommandOptions returns the options of the invoked (sub)command, skipping group/subcommand levels
+func SubcommandOptions(data discordgo.ApplicationCommandInteractionData) []*discordgo.ApplicationCommandInteractionDataOption {
+	options := data.Options
+
+	for len(options) > 0 {
+		opt := options[0]
+		if opt.Type != discordgo.ApplicationCommandOptionSubCommandGroup &&
+			opt.Type != discordgo.ApplicationCommandOptionSubCommand {
+			break
+		}
+		options = opt.Options
+	}
+
+	return options
+}
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"log"
+	"strings"
+
+	"discord-bot-template/internal/component"
+	"discord-bot-template/internal/embed"
+	"discord-bot-template/internal/i18n"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ============================================
+// Modal Validation (驗證失敗時重新開啟 Modal)
+// ============================================
+
+func init() {
+	RegisterComponent("modal_retry:{state}", modalRetryHandler)
+}
+
+// modalRetry is stored behind the "Try again" button
+type modalRetry struct {
+	ModalID string            `json:"modal_id"`
+	Values  map[string]string `json:"values"`
+}
+
+// RejectInvalidModal checks a modal submission against the validators given to its builder
+// (see component.TextInputBuilder.Validate) and the rules of its route (see WithValidators).
+// If any fail it replies with the errors and returns true so the submission is not handled
+// further. While the modal can be rebuilt (see component.CanRetryModal), the reply has a
+// "Try again" button that reopens it with the user's answers.
+func RejectInvalidModal(s *discordgo.Session, i *discordgo.InteractionCreate, rules map[string]component.InputRule) bool {
+	if i.Type != discordgo.InteractionModalSubmit {
+		return false
+	}
+	data := i.ModalSubmitData()
+
+	errs := component.ValidateModal(data, rules)
+	if len(errs) == 0 {
+		return false
+	}
+
+	t := i18n.ForInteraction(i)
+	lines := make([]string, len(errs))
+	for idx, err := range errs {
+		lines[idx] = "• " + embed.Bold(err.Label) + ": " + t.Error(err.Err)
+	}
+	reply := &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{embed.Error(t.T("validation.title"), strings.Join(lines, "\n"))},
+		Flags:  discordgo.MessageFlagsEphemeral,
+	}
+
+	if component.CanRetryModal(data.CustomID) {
+		retry := modalRetry{ModalID: data.CustomID, Values: component.GetModalValues(data)}
+		retryID, err := component.NewStateID("modal_retry", retry, component.ModalValidationTTL)
+		if err != nil {
+			log.Printf("Failed to store answers of %s: %v", DescribeInteraction(i), err)
+		} else {
+			reply.Components = []discordgo.MessageComponent{
+				component.SingleButtonRow(component.PrimaryButton(retryID, t.T("validation.retry"))),
+			}
+		}
+	}
+
+	if err := NewResponse(s, i).Reply(reply); err != nil {
+		log.Printf("Failed to reject %s: %v", DescribeInteraction(i), err)
+	}
+	return true
+}
+
+// modalRetryHandler reopens a modal prefilled with the rejected answers
+func modalRetryHandler(ctx *Context) error {
+	var retry modalRetry
+	ok, err := ctx.State(&retry)
+	if err != nil {
+		return err
+	}
+
+	var modal *discordgo.InteractionResponse
+	if ok {
+		modal = component.RetryModal(retry.ModalID, retry.Values)
+	}
+	if modal == nil {
+		return &UserError{Title: ctx.T("errors.expired.title"), Message: ctx.T("errors.expired.message")}
+	}
+	return ctx.Respond(modal)
+}
@@ -0,0 +1,293 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"discord-bot-template/internal/component"
+	"discord-bot-template/internal/embed"
+	"discord-bot-template/internal/state"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ============================================
+// Modal Wizard (多頁 Modal)
+// ============================================
+//
+// A wizard chains several modals. Discord cannot open a modal from a modal
+// submit, so after each step the user gets a "Continue" button that opens
+// the next one. Answers are kept in the state store between steps.
+//
+//	var signupWizard = &Wizard{
+//	    Name: "signup",
+//	    Steps: []*WizardStep{
+//	        {Title: "About you", Inputs: []discordgo.TextInput{component.ShortInput("name", "Name", "")}},
+//	        {Title: "Company", Inputs: ..., When: func(a WizardAnswers) bool { return a["type"] == "business" }},
+//	    },
+//	    OnComplete: func(ctx *Context, answers WizardAnswers) error { ... },
+//	}
+//
+//	func init() { RegisterWizard(signupWizard) }
+//	func SignupHandler(ctx *Context) error { return signupWizard.Start(ctx) }
+
+// DefaultWizardTTL is how long an unfinished wizard's answers are kept
+const DefaultWizardTTL = 30 * time.Minute
+
+// WizardAnswers holds every submitted value so far, keyed by text input custom ID
+type WizardAnswers map[string]string
+
+// WizardStep is one modal of a wizard (at most 5 inputs)
+type WizardStep struct {
+	Title    string
+	Inputs   []discordgo.TextInput
+	Validate map[string][]component.Validator // Validators by input custom ID
+	When     func(answers WizardAnswers) bool // Show this step only if true (nil = always)
+}
+
+// Wizard is a sequence of modals with a single completion handler
+type Wizard struct {
+	Name       string // Unique name used in custom IDs (no ":")
+	Steps      []*WizardStep
+	OnComplete func(ctx *Context, answers WizardAnswers) error
+	TTL        time.Duration // How long unfinished answers are kept (default DefaultWizardTTL)
+}
+
+// wizardSession is what the state store keeps between steps
+type wizardSession struct {
+	UserID  string        `json:"user_id"`
+	Answers WizardAnswers `json:"answers"`
+}
+
+// RegisterWizard registers the wizard's modal and button handlers (call in init()).
+// An invalid wizard panics.
+func RegisterWizard(w *Wizard, opts ...RouteOption) {
+	if w.Name == "" || strings.Contains(w.Name, ":") {
+		panic(fmt.Sprintf("register wizard %q: name must be non-empty and not contain ':'", w.Name))
+	}
+	for idx, step := range w.Steps {
+		if len(step.Inputs) == 0 || len(step.Inputs) > 5 {
+			panic(fmt.Sprintf("register wizard %q: step %d must have 1-5 inputs", w.Name, idx+1))
+		}
+	}
+	if w.TTL == 0 {
+		w.TTL = DefaultWizardTTL
+	}
+
+	// Step validators also go on the routes, so sessions that outlive the process are still validated
+	modalOpts := append([]RouteOption{}, opts...)
+	for _, step := range w.Steps {
+		for _, input := range step.Inputs {
+			if validators := step.Validate[input.CustomID]; len(validators) > 0 {
+				modalOpts = append(modalOpts, WithValidators(input.CustomID, input.Label, validators...))
+			}
+		}
+	}
+
+	RegisterModal(w.customID("wizard", "{step}", "{session}"), w.handleSubmit, modalOpts...)
+	RegisterModal(w.customID("wizard_continued", "{step}", "{session}"), w.handleSubmit, modalOpts...)
+	RegisterComponent(w.customID("wizard_next", "{step}", "{session}"), w.handleContinue, opts...)
+	RegisterComponent(w.customID("wizard_cancel", "{session}"), w.handleCancel, opts...)
+}
+
+// Start opens the first step. This must be the first response to the interaction.
+func (w *Wizard) Start(ctx *Context) error {
+	step := w.nextStep(-1, WizardAnswers{})
+	if step < 0 {
+		return fmt.Errorf("wizard %q has no steps", w.Name)
+	}
+
+	session := component.NewToken()
+
+	err := state.Default().Set(wizardKey(session), wizardSession{
+		UserID:  ctx.User().ID,
+		Answers: WizardAnswers{},
+	}, w.TTL)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Respond(w.modal(step, session, WizardAnswers{}, false))
+}
+
+// ============================================
+// Handlers
+// ============================================
+
+// handleSubmit stores a step's answers, then shows the Continue button or completes the wizard
+func (w *Wizard) handleSubmit(ctx *Context) error {
+	step, sessionID, ws, err := w.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	for id, value := range component.GetModalValues(ctx.Interaction.ModalSubmitData()) {
+		ws.Answers[id] = value
+	}
+
+	// Opened from the progress message's Continue button (rather than from whatever
+	// message Start was called on, which Discord also reports as the modal's message)
+	continued := strings.HasPrefix(ctx.CustomID(), w.customID("wizard_continued"))
+
+	next := w.nextStep(step, ws.Answers)
+	if next < 0 {
+		if err := state.Default().Delete(wizardKey(sessionID)); err != nil {
+			log.Printf("Failed to delete wizard session: %v", err)
+		}
+
+		// Close the progress message, the completion handler's reply becomes a follow-up
+		if continued {
+			err := ctx.Update(&discordgo.InteractionResponseData{
+				Embeds:     embed.New().Description(ctx.T("wizard.completed")).Color(embed.ColorSuccess).BuildSlice(),
+				Components: []discordgo.MessageComponent{},
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return w.OnComplete(ctx, ws.Answers)
+	}
+
+	if err := state.Default().Set(wizardKey(sessionID), ws, w.TTL); err != nil {
+		return err
+	}
+
+	e := embed.New().
+		Title(w.Steps[step].Title + " ✅").
+		Description(ctx.T("wizard.progress", w.position(step, ws.Answers), w.total(ws.Answers))).
+		Color(embed.ColorInfo)
+
+	row := component.NewActionRow().
+		AddButton(component.PrimaryButton(w.customID("wizard_next", strconv.Itoa(next), sessionID), ctx.T("wizard.continue"))).
+		AddButton(component.SecondaryButton(w.customID("wizard_cancel", sessionID), ctx.T("wizard.cancel"))).
+		Build()
+
+	// Submitted from a Continue button: replace that message instead of sending a new one
+	if continued {
+		return ctx.UpdateEmbed(e, row)
+	}
+	return ctx.ReplyEphemeral(e, row)
+}
+
+// handleContinue opens the next step's modal
+func (w *Wizard) handleContinue(ctx *Context) error {
+	step, sessionID, ws, err := w.load(ctx)
+	if err != nil {
+		return err
+	}
+	return ctx.Respond(w.modal(step, sessionID, ws.Answers, true))
+}
+
+// handleCancel discards the answers
+func (w *Wizard) handleCancel(ctx *Context) error {
+	sessionID := ctx.Params.Get("session")
+
+	var ws wizardSession
+	if ok, err := state.Default().Get(wizardKey(sessionID), &ws); err == nil && ok && ws.UserID != ctx.User().ID {
+		return &UserError{Message: ctx.T("wizard.only_owner_cancel")}
+	}
+	if err := state.Default().Delete(wizardKey(sessionID)); err != nil {
+		return err
+	}
+
+	return ctx.Update(&discordgo.InteractionResponseData{
+		Embeds:     []*discordgo.MessageEmbed{embed.Info(ctx.T("wizard.cancelled.title"), ctx.T("wizard.cancelled.message"))},
+		Components: []discordgo.MessageComponent{},
+	})
+}
+
+// load reads the step and session from ctx.Params and checks the user
+func (w *Wizard) load(ctx *Context) (int, string, *wizardSession, error) {
+	step, err := strconv.Atoi(ctx.Params.Get("step"))
+	if err != nil || step < 0 || step >= len(w.Steps) {
+		return 0, "", nil, fmt.Errorf("wizard %q: invalid step %q", w.Name, ctx.Params.Get("step"))
+	}
+
+	sessionID := ctx.Params.Get("session")
+	ws := &wizardSession{}
+	ok, err := state.Default().Get(wizardKey(sessionID), ws)
+	if err != nil {
+		return 0, "", nil, err
+	}
+	if !ok {
+		return 0, "", nil, &UserError{Title: ctx.T("errors.expired.title"), Message: ctx.T("wizard.expired")}
+	}
+	if ws.UserID != ctx.User().ID {
+		return 0, "", nil, &UserError{Message: ctx.T("wizard.only_owner_continue")}
+	}
+	if ws.Answers == nil {
+		ws.Answers = WizardAnswers{}
+	}
+	return step, sessionID, ws, nil
+}
+
+// ============================================
+// Helpers
+// ============================================
+
+// modal builds a step's modal, prefilled with earlier answers.
+// continued marks modals opened from the progress message's Continue button.
+func (w *Wizard) modal(step int, sessionID string, answers WizardAnswers, continued bool) *discordgo.InteractionResponse {
+	s := w.Steps[step]
+
+	title := fmt.Sprintf("%s (%d/%d)", s.Title, w.position(step, answers), w.total(answers))
+	if len(title) > 45 {
+		// Modal titles are limited to 45 characters
+		title = s.Title
+	}
+
+	kind := "wizard"
+	if continued {
+		kind = "wizard_continued"
+	}
+	modal := component.NewModal().
+		CustomID(w.customID(kind, strconv.Itoa(step), sessionID)).
+		Title(title)
+	for _, input := range s.Inputs {
+		if value, ok := answers[input.CustomID]; ok {
+			input.Value = value
+		}
+		modal.AddTextInput(input).Validate(input.CustomID, s.Validate[input.CustomID]...)
+	}
+	return modal.Build()
+}
+
+// nextStep returns the first step after `after` whose condition passes (-1 = done)
+func (w *Wizard) nextStep(after int, answers WizardAnswers) int {
+	for idx := after + 1; idx < len(w.Steps); idx++ {
+		if w.Steps[idx].When == nil || w.Steps[idx].When(answers) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// position returns the 1-based number of a step among the steps shown for these answers
+func (w *Wizard) position(step int, answers WizardAnswers) int {
+	n := 0
+	for idx := w.nextStep(-1, answers); idx >= 0 && idx <= step; idx = w.nextStep(idx, answers) {
+		n++
+	}
+	return max(n, 1)
+}
+
+// total returns the number of steps shown for these answers (later conditions may still change it)
+func (w *Wizard) total(answers WizardAnswers) int {
+	n := 0
+	for idx := w.nextStep(-1, answers); idx >= 0; idx = w.nextStep(idx, answers) {
+		n++
+	}
+	return n
+}
+
+// customID builds "kind:name:part:part" custom IDs, e.g. "wizard:signup:1:1a2b3c"
+func (w *Wizard) customID(kind string, parts ...string) string {
+	return kind + ":" + w.Name + ":" + strings.Join(parts, ":")
+}
+
+func wizardKey(sessionID string) string {
+	return "wizard:" + sessionID
+}
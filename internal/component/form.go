@@ -0,0 +1,322 @@
+package component
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"discord-bot-template/internal/i18n"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ============================================
+// Typed Modals (struct tag 綁定)
+// ============================================
+//
+// Declare the inputs as a struct:
+//
+//	type ReportForm struct {
+//	    Title    string        `input:"title,required" label:"Title" maxlen:"100"`
+//	    Details  string        `input:"details,required,paragraph" label:"What happened?" minlen:"20"`
+//	    Severity int           `input:"severity" label:"Severity (1-5)" placeholder:"3"`
+//	    Snooze   time.Duration `input:"snooze" label:"Remind me in" placeholder:"1h30m"`
+//	    Due      time.Time     `input:"due" label:"Due date" layout:"2006-01-02"`
+//	}
+//
+//	modal, err := component.ModalFor("report", "Report a bug", ReportForm{})
+//	...
+//	var form ReportForm
+//	err := component.DecodeModal(i.ModalSubmitData(), &form)
+//
+// Supported tags:
+//   - input:       custom ID[,required][,paragraph]
+//   - label:       input label (defaults to the field name)
+//   - placeholder: placeholder text
+//   - minlen/maxlen: length range
+//   - layout:      time.Time format (default "2006-01-02")
+//
+// Supported field types: string, int*, uint*, float*, bool (yes/no, true/false),
+// time.Duration ("1h30m"), time.Time, or pointers to them (nil when left empty).
+// Non-string fields get a validator so bad values are rejected with a "Try again" button.
+
+// DefaultTimeLayout is the time.Time format used when a field has no layout tag
+const DefaultTimeLayout = "2006-01-02"
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// inputTag is the parsed form of `input:"id,required,paragraph"`
+type inputTag struct {
+	id        string
+	required  bool
+	paragraph bool
+}
+
+// parseInputTag reads the input tag; fields without the tag are skipped
+func parseInputTag(field reflect.StructField) (inputTag, bool) {
+	raw, ok := field.Tag.Lookup("input")
+	if !ok || raw == "-" || !field.IsExported() {
+		return inputTag{}, false
+	}
+
+	parts := strings.Split(raw, ",")
+	tag := inputTag{id: parts[0]}
+	if tag.id == "" {
+		tag.id = strings.ToLower(field.Name)
+	}
+	for _, flag := range parts[1:] {
+		switch flag {
+		case "required":
+			tag.required = true
+		case "paragraph":
+			tag.paragraph = true
+		}
+	}
+	return tag, true
+}
+
+// ModalFor builds a modal from the struct v (see the tags above).
+// Non-zero fields of v are used as prefilled values.
+func ModalFor(customID, title string, v interface{}) (*ModalBuilder, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("modal type %T is not a struct", v)
+	}
+
+	modal := NewModal().CustomID(customID).Title(title)
+	count := 0
+	for _, field := range reflect.VisibleFields(rv.Type()) {
+		tag, ok := parseInputTag(field)
+		if !ok {
+			continue
+		}
+		count++
+		if count > 5 {
+			return nil, fmt.Errorf("modal %T has more than 5 inputs", v)
+		}
+
+		input, err := buildInput(field, tag)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if value := rv.FieldByIndex(field.Index); !value.IsZero() {
+			input.Value(formatInputValue(value, timeLayout(field)))
+		}
+		modal.AddInput(input)
+	}
+
+	if count == 0 {
+		return nil, fmt.Errorf("modal %T has no input fields", v)
+	}
+	return modal, nil
+}
+
+func buildInput(field reflect.StructField, tag inputTag) (*TextInputBuilder, error) {
+	if !isInputType(field.Type) {
+		return nil, fmt.Errorf("unsupported input type %s", field.Type)
+	}
+
+	label := field.Tag.Get("label")
+	if label == "" {
+		label = field.Name
+	}
+
+	input := NewTextInput().
+		CustomID(tag.id).
+		Label(label).
+		Placeholder(field.Tag.Get("placeholder"))
+	if tag.paragraph {
+		input.Paragraph()
+	}
+	if tag.required {
+		input.Required()
+	}
+
+	if v, ok := field.Tag.Lookup("minlen"); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid minlen %q", v)
+		}
+		input.MinLength(n)
+	}
+	if v, ok := field.Tag.Lookup("maxlen"); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maxlen %q", v)
+		}
+		input.MaxLength(n)
+	}
+
+	// Reject values that wouldn't decode before the handler sees them
+	if inputKind(field.Type) != reflect.String {
+		t, layout := field.Type, timeLayout(field)
+		input.Validate(func(value string) error {
+			return decodeInputValue(reflect.New(t).Elem(), value, layout)
+		})
+	}
+
+	return input, nil
+}
+
+// ============================================
+// Decoding
+// ============================================
+
+// DecodeModal fills the struct pointed to by dst from a modal submission.
+// Empty inputs leave their fields at the zero value (nil for pointers).
+// Invalid answers are reported as i18n errors (see Translator.Error).
+func DecodeModal(data discordgo.ModalSubmitInteractionData, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("destination must be a pointer to a struct")
+	}
+	v = v.Elem()
+
+	values := GetModalValues(data)
+	for _, field := range reflect.VisibleFields(v.Type()) {
+		tag, ok := parseInputTag(field)
+		if !ok {
+			continue
+		}
+
+		label := field.Tag.Get("label")
+		if label == "" {
+			label = field.Name
+		}
+
+		value := values[tag.id]
+		if value == "" {
+			if tag.required {
+				return i18n.Errorf("validation.required", label)
+			}
+			continue
+		}
+
+		if err := decodeInputValue(v.FieldByIndex(field.Index), value, timeLayout(field)); err != nil {
+			return i18n.Errorf("validation.field", label, err)
+		}
+	}
+
+	return nil
+}
+
+// decodeInputValue converts a submitted value into fv. Errors read as "<label> must be ..." (see validation.field).
+func decodeInputValue(fv reflect.Value, value, layout string) error {
+	// Optional fields: allocate the pointer so the handler can tell "empty" from zero
+	if fv.Kind() == reflect.Pointer {
+		fv.Set(reflect.New(fv.Type().Elem()))
+		fv = fv.Elem()
+	}
+	value = strings.TrimSpace(value)
+
+	switch fv.Type() {
+	case durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return i18n.Errorf("validation.duration")
+		}
+		fv.SetInt(int64(d))
+		return nil
+	case timeType:
+		t, err := time.Parse(layout, value)
+		if err != nil {
+			return i18n.Errorf("validation.date", layout)
+		}
+		fv.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, fv.Type().Bits())
+		if err != nil {
+			return i18n.Errorf("validation.integer")
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, fv.Type().Bits())
+		if err != nil {
+			return i18n.Errorf("validation.unsigned")
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, fv.Type().Bits())
+		if err != nil {
+			return i18n.Errorf("validation.number")
+		}
+		fv.SetFloat(f)
+	case reflect.Bool:
+		switch strings.ToLower(value) {
+		case "yes", "y", "true", "1":
+			fv.SetBool(true)
+		case "no", "n", "false", "0":
+			fv.SetBool(false)
+		default:
+			return i18n.Errorf("validation.yes_no")
+		}
+	default:
+		return fmt.Errorf("has unsupported type %s", fv.Type())
+	}
+
+	return nil
+}
+
+// formatInputValue renders a field value for prefilling an input
+func formatInputValue(v reflect.Value, layout string) string {
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+
+	switch v.Type() {
+	case durationType:
+		return time.Duration(v.Int()).String()
+	case timeType:
+		return v.Interface().(time.Time).Format(layout)
+	}
+	if v.Kind() == reflect.Bool {
+		if v.Bool() {
+			return "yes"
+		}
+		return "no"
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// isInputType reports whether a field type can be decoded from a text input
+func isInputType(t reflect.Type) bool {
+	switch inputKind(t) {
+	case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	case reflect.Struct:
+		return inputElem(t) == timeType
+	}
+	return false
+}
+
+// inputKind returns the kind of a field, looking through pointers
+func inputKind(t reflect.Type) reflect.Kind {
+	return inputElem(t).Kind()
+}
+
+func inputElem(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
+
+func timeLayout(field reflect.StructField) string {
+	if layout := field.Tag.Get("layout"); layout != "" {
+		return layout
+	}
+	return DefaultTimeLayout
+}
@@ -0,0 +1,102 @@
+package component
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ============================================
+// Dynamic Listeners (不需在 init() 註冊的元件)
+// ============================================
+
+// ListenerResponder answers a listener's interaction. *commands.Response satisfies it,
+// so listeners share the handlers' defer state and allowed mentions.
+type ListenerResponder interface {
+	Respond(resp *discordgo.InteractionResponse) error
+	Reply(data *discordgo.InteractionResponseData) error
+	Update(data *discordgo.InteractionResponseData) error
+	Edit(edit *discordgo.WebhookEdit) (*discordgo.Message, error)
+}
+
+// ListenerFunc handles an interaction on a dynamically created component or modal.
+// action is the part of the custom ID after the listener key ("pg:1a2b:next" → "next").
+type ListenerFunc func(r ListenerResponder, i *discordgo.InteractionCreate, action string) error
+
+var listeners = struct {
+	sync.RWMutex
+	byKey map[string]ListenerFunc
+}{byKey: make(map[string]ListenerFunc)}
+
+// NewToken returns a short random token like "1a2b3c4d5e6f" for use in custom IDs
+func NewToken() string {
+	buf := make([]byte, 6)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// NewListenerKey returns a unique key like "pg:1a2b3c4d5e6f" for a listener
+func NewListenerKey(prefix string) string {
+	return prefix + ":" + NewToken()
+}
+
+// ListenerID builds the custom ID of a listener's component ("pg:1a2b" + "next" → "pg:1a2b:next")
+func ListenerID(key, action string) string {
+	return key + ":" + action
+}
+
+// Listen routes components/modals whose custom ID is key:<action> to fn until Unlisten is called
+func Listen(key string, fn ListenerFunc) {
+	listeners.Lock()
+	defer listeners.Unlock()
+	listeners.byKey[key] = fn
+}
+
+// Unlisten removes a listener
+func Unlisten(key string) {
+	listeners.Lock()
+	defer listeners.Unlock()
+	delete(listeners.byKey, key)
+}
+
+// Dispatch runs the listener for a component or modal interaction.
+// It returns false if no listener matches, so the caller can fall back to registered handlers.
+// The listener answers through r.
+func Dispatch(r ListenerResponder, i *discordgo.InteractionCreate) (bool, error) {
+	var customID string
+	switch i.Type {
+	case discordgo.InteractionMessageComponent:
+		customID = i.MessageComponentData().CustomID
+	case discordgo.InteractionModalSubmit:
+		customID = i.ModalSubmitData().CustomID
+	default:
+		return false, nil
+	}
+
+	fn, action := lookupListener(customID)
+	if fn == nil {
+		return false, nil
+	}
+	return true, fn(r, i, action)
+}
+
+// Listening reports whether a listener handles this custom ID
+func Listening(customID string) bool {
+	fn, _ := lookupListener(customID)
+	return fn != nil
+}
+
+// lookupListener returns the listener of a custom ID and its action
+func lookupListener(customID string) (ListenerFunc, string) {
+	idx := strings.LastIndex(customID, ":")
+	if idx < 0 {
+		return nil, ""
+	}
+
+	listeners.RLock()
+	defer listeners.RUnlock()
+	return listeners.byKey[customID[:idx]], customID[idx+1:]
+}
@@ -8,7 +8,8 @@ import "github.com/bwmarrin/discordgo"
 
 // TextInputBuilder builds a text input for modals
 type TextInputBuilder struct {
-	input discordgo.TextInput
+	input      discordgo.TextInput
+	validators []Validator
 }
 
 // NewTextInput creates a new text input builder
@@ -80,6 +81,12 @@ func (t *TextInputBuilder) MaxLength(max int) *TextInputBuilder {
 	return t
 }
 
+// Validate adds validators that run when the modal is submitted (see ModalBuilder.AddInput)
+func (t *TextInputBuilder) Validate(validators ...Validator) *TextInputBuilder {
+	t.validators = append(t.validators, validators...)
+	return t
+}
+
 // Build returns the text input component
 func (t *TextInputBuilder) Build() discordgo.TextInput {
 	return t.input
@@ -94,6 +101,7 @@ type ModalBuilder struct {
 	customID   string
 	title      string
 	components []discordgo.MessageComponent
+	validators map[string][]Validator
 }
 
 // NewModal creates a new modal builder
@@ -125,9 +133,29 @@ func (m *ModalBuilder) AddTextInput(input discordgo.TextInput) *ModalBuilder {
 	return m
 }
 
-// Build returns the modal response
+// AddInput adds a text input together with its validators
+func (m *ModalBuilder) AddInput(t *TextInputBuilder) *ModalBuilder {
+	input := t.Build()
+	m.AddTextInput(input)
+	return m.Validate(input.CustomID, t.validators...)
+}
+
+// Validate adds validators for a text input added with AddTextInput
+func (m *ModalBuilder) Validate(inputID string, validators ...Validator) *ModalBuilder {
+	if len(validators) == 0 {
+		return m
+	}
+	if m.validators == nil {
+		m.validators = make(map[string][]Validator)
+	}
+	m.validators[inputID] = append(m.validators[inputID], validators...)
+	return m
+}
+
+// Build returns the modal response.
+// If the modal has validators they are remembered for its custom ID (see ValidateModal).
 func (m *ModalBuilder) Build() *discordgo.InteractionResponse {
-	return &discordgo.InteractionResponse{
+	resp := &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseModal,
 		Data: &discordgo.InteractionResponseData{
 			CustomID:   m.customID,
@@ -135,6 +163,10 @@ func (m *ModalBuilder) Build() *discordgo.InteractionResponse {
 			Components: m.components,
 		},
 	}
+	if len(m.validators) > 0 {
+		registerModalSpec(&modalSpec{modal: resp, validators: m.validators})
+	}
+	return resp
 }
 
 // ============================================
@@ -227,3 +259,18 @@ func GetModalValue(data discordgo.ModalSubmitInteractionData, customID string) s
 	}
 	return ""
 }
+
+// GetModalValues extracts all values from modal submit data, keyed by input custom ID
+func GetModalValues(data discordgo.ModalSubmitInteractionData) map[string]string {
+	values := make(map[string]string)
+	for _, row := range data.Components {
+		if actionRow, ok := row.(*discordgo.ActionsRow); ok {
+			for _, comp := range actionRow.Components {
+				if input, ok := comp.(*discordgo.TextInput); ok {
+					values[input.CustomID] = input.Value
+				}
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,286 @@
+package component
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"discord-bot-template/internal/auth"
+	"discord-bot-template/internal/embed"
+	"discord-bot-template/internal/i18n"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ============================================
+// Paginator (分頁元件)
+// ============================================
+
+// PageFunc produces the embed for a page (0-based)
+type PageFunc func(page int) (*discordgo.MessageEmbed, error)
+
+// Responder is what a paginator needs from the interaction that opens it.
+// *commands.Context satisfies it.
+type Responder interface {
+	User() *discordgo.User
+	ReplyMessage(data *discordgo.InteractionResponseData) (messageID string, err error)
+	EditMessage(messageID string, edit *discordgo.WebhookEdit) (*discordgo.Message, error)
+}
+
+const (
+	// DefaultPaginatorTimeout is how long a paginator stays active after the last click
+	DefaultPaginatorTimeout = 3 * time.Minute
+
+	// maxPaginatorTimeout keeps the timeout below the 15 minute interaction token lifetime,
+	// so the buttons can still be disabled when it expires
+	maxPaginatorTimeout = 14 * time.Minute
+)
+
+// Paginator shows one embed at a time with ⏮ ◀ [page/total] ▶ ⏭ buttons.
+// Clicking the page counter opens a "go to page" modal. Only the user who opened it
+// can use the controls, and the buttons are disabled after Timeout without clicks.
+//
+//	component.NewPaginator(pages).Ephemeral().Send(ctx)
+//	component.NewPaginatorFunc(total, func(page int) (*discordgo.MessageEmbed, error) { ... }).Send(ctx)
+type Paginator struct {
+	pages     PageFunc
+	count     int
+	page      int
+	ownerID   string
+	timeout   time.Duration
+	ephemeral bool
+
+	mu    sync.Mutex
+	key   string
+	timer *time.Timer
+	edit  func(*discordgo.WebhookEdit) error // Edits the message with the most recent interaction token
+}
+
+// NewPaginator creates a paginator over a fixed list of embeds
+func NewPaginator(pages []*discordgo.MessageEmbed) *Paginator {
+	return NewPaginatorFunc(len(pages), func(page int) (*discordgo.MessageEmbed, error) {
+		return pages[page], nil
+	})
+}
+
+// NewPaginatorFunc creates a paginator whose pages are produced on demand
+func NewPaginatorFunc(count int, pages PageFunc) *Paginator {
+	return &Paginator{
+		pages:   pages,
+		count:   count,
+		timeout: DefaultPaginatorTimeout,
+	}
+}
+
+// Page sets the page shown first (0-based)
+func (p *Paginator) Page(page int) *Paginator {
+	p.page = page
+	return p
+}
+
+// Owner restricts the controls to a user (defaults to the user who opened it)
+func (p *Paginator) Owner(userID string) *Paginator {
+	p.ownerID = userID
+	return p
+}
+
+// Timeout sets how long the paginator stays active after the last click (max 14 minutes)
+func (p *Paginator) Timeout(timeout time.Duration) *Paginator {
+	p.timeout = timeout
+	return p
+}
+
+// Ephemeral sends the paginator as a private message
+func (p *Paginator) Ephemeral() *Paginator {
+	p.ephemeral = true
+	return p
+}
+
+// Send replies with the first page and starts listening for clicks
+func (p *Paginator) Send(r Responder) error {
+	if p.count < 1 {
+		return errors.New("paginator has no pages")
+	}
+	if p.ownerID == "" && r.User() != nil {
+		p.ownerID = r.User().ID
+	}
+	if p.timeout <= 0 || p.timeout > maxPaginatorTimeout {
+		p.timeout = maxPaginatorTimeout
+	}
+	p.page = clamp(p.page, 0, p.count-1)
+
+	e, err := p.pages(p.page)
+	if err != nil {
+		return err
+	}
+
+	data := &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{e},
+	}
+	if p.ephemeral {
+		data.Flags = discordgo.MessageFlagsEphemeral
+	}
+
+	// A single page needs no controls
+	if p.count == 1 {
+		_, err := r.ReplyMessage(data)
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.key = NewListenerKey("pg")
+	data.Components = p.components(false)
+
+	// After a defer or an earlier reply, the paginator is a follow-up message
+	messageID, err := r.ReplyMessage(data)
+	if err != nil {
+		return err
+	}
+
+	p.edit = func(edit *discordgo.WebhookEdit) error {
+		_, err := r.EditMessage(messageID, edit)
+		return err
+	}
+	p.timer = time.AfterFunc(p.timeout, p.expire)
+	Listen(p.key, p.handle)
+	return nil
+}
+
+// handle processes a click on one of the paginator's buttons or the jump modal
+func (p *Paginator) handle(r ListenerResponder, i *discordgo.InteractionCreate, action string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	t := i18n.ForInteraction(i)
+	if userID := auth.InteractionUserID(i); userID != p.ownerID {
+		return replyEphemeral(r, embed.Error(t.T("paginator.not_yours.title"),
+			t.T("paginator.not_yours.message", embed.Mention(p.ownerID))))
+	}
+
+	page := p.page
+	switch action {
+	case "first":
+		page = 0
+	case "prev":
+		page--
+	case "next":
+		page++
+	case "last":
+		page = p.count - 1
+	case "jump":
+		return r.Respond(p.jumpModal(t))
+	case "goto":
+		n, err := strconv.Atoi(strings.TrimSpace(GetModalValue(i.ModalSubmitData(), "page")))
+		if err != nil || n < 1 || n > p.count {
+			return replyEphemeral(r, embed.Error(t.T("paginator.invalid_page.title"),
+				t.T("paginator.invalid_page.message", p.count)))
+		}
+		page = n - 1
+	default:
+		return fmt.Errorf("unknown paginator action %q", action)
+	}
+	page = clamp(page, 0, p.count-1)
+
+	e, err := p.pages(page)
+	if err != nil {
+		log.Printf("Failed to render page %d: %v", page+1, err)
+		return replyEphemeral(r, embed.Error(t.T("errors.generic.title"), t.T("paginator.load_failed")))
+	}
+	p.page = page
+
+	err = r.Update(&discordgo.InteractionResponseData{
+		Embeds:     []*discordgo.MessageEmbed{e},
+		Components: p.components(false),
+	})
+	if err != nil {
+		return err
+	}
+
+	// The click's token stays valid for 15 minutes, so use it to disable the buttons later
+	p.edit = func(edit *discordgo.WebhookEdit) error {
+		_, err := r.Edit(edit)
+		return err
+	}
+	p.timer.Reset(p.timeout)
+	return nil
+}
+
+// expire stops listening and disables the buttons
+func (p *Paginator) expire() {
+	Unlisten(p.key)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	components := p.components(true)
+	if err := p.edit(&discordgo.WebhookEdit{Components: &components}); err != nil {
+		log.Printf("Failed to disable paginator %s: %v", p.key, err)
+	}
+}
+
+// components renders the navigation row
+func (p *Paginator) components(disabled bool) []discordgo.MessageComponent {
+	first := p.page == 0
+	last := p.page == p.count-1
+
+	row := NewActionRow().
+		AddButton(navButton(ListenerID(p.key, "first"), "⏮", "", disabled || first)).
+		AddButton(navButton(ListenerID(p.key, "prev"), "◀", "", disabled || first)).
+		AddButton(navButton(ListenerID(p.key, "jump"), "", fmt.Sprintf("%d / %d", p.page+1, p.count), disabled)).
+		AddButton(navButton(ListenerID(p.key, "next"), "▶", "", disabled || last)).
+		AddButton(navButton(ListenerID(p.key, "last"), "⏭", "", disabled || last)).
+		Build()
+
+	return []discordgo.MessageComponent{row}
+}
+
+// jumpModal asks for a page number
+func (p *Paginator) jumpModal(t *i18n.Translator) *discordgo.InteractionResponse {
+	input := NewTextInput().
+		CustomID("page").
+		Label(t.T("paginator.jump.label", p.count)).
+		Placeholder(strconv.Itoa(p.page + 1)).
+		Short().
+		Required().
+		MaxLength(len(strconv.Itoa(p.count))).
+		Build()
+
+	return NewModal().
+		CustomID(ListenerID(p.key, "goto")).
+		Title(t.T("paginator.jump.title")).
+		AddTextInput(input).
+		Build()
+}
+
+func navButton(customID, emoji, label string, disabled bool) discordgo.Button {
+	btn := NewButton().
+		CustomID(customID).
+		Secondary()
+	if emoji != "" {
+		btn.Emoji(emoji)
+	}
+	if label != "" {
+		btn.Label(label)
+	}
+	if disabled {
+		btn.Disabled()
+	}
+	return btn.Build()
+}
+
+func replyEphemeral(r ListenerResponder, e *discordgo.MessageEmbed) error {
+	return r.Reply(&discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{e},
+		Flags:  discordgo.MessageFlagsEphemeral,
+	})
+}
+
+func clamp(n, lo, hi int) int {
+	return max(lo, min(n, hi))
+}
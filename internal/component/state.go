@@ -0,0 +1,60 @@
+package component
+
+import (
+	"strings"
+	"time"
+
+	"discord-bot-template/internal/state"
+)
+
+// ============================================
+// Stored State (custom ID → state store)
+// ============================================
+
+// NewStateID stores value and returns a short opaque custom ID like "vote:1a2b3c4d5e6f".
+// Register a handler for "prefix:{state}" and load the value with LoadState.
+// Like state.Store, a ttl <= 0 keeps the state until it is deleted.
+//
+//	id, err := component.NewStateID("vote", Vote{PollID: 42, Option: "yes"}, time.Hour)
+//	button := component.PrimaryButton(id, "Yes")
+func NewStateID(prefix string, value interface{}, ttl time.Duration) (string, error) {
+	customID := prefix + ":" + NewToken()
+	if err := state.Default().Set(stateKey(customID), value, ttl); err != nil {
+		return "", err
+	}
+	return customID, nil
+}
+
+// SharedStateID returns a custom ID with another prefix that points to the same state,
+// e.g. for Yes / No buttons on one poll
+//
+//	yes, _ := component.NewStateID("vote_yes", poll, time.Hour)
+//	no := component.SharedStateID("vote_no", yes)
+func SharedStateID(prefix, customID string) string {
+	return prefix + ":" + strings.TrimPrefix(stateKey(customID), "component:")
+}
+
+// LoadState decodes the state behind a custom ID created by NewStateID.
+// It returns false if the state has expired or never existed.
+func LoadState(customID string, dst interface{}) (bool, error) {
+	return state.Default().Get(stateKey(customID), dst)
+}
+
+// UpdateState replaces the state behind a custom ID (ttl <= 0 = never expires)
+func UpdateState(customID string, value interface{}, ttl time.Duration) error {
+	return state.Default().Set(stateKey(customID), value, ttl)
+}
+
+// DeleteState removes the state behind a custom ID
+func DeleteState(customID string) error {
+	return state.Default().Delete(stateKey(customID))
+}
+
+// stateKey uses the token after the prefix, so "vote_yes:1a2b" and "vote_no:1a2b" share state
+func stateKey(customID string) string {
+	token := customID
+	if idx := strings.LastIndex(customID, ":"); idx >= 0 {
+		token = customID[idx+1:]
+	}
+	return "component:" + token
+}
@@ -0,0 +1,280 @@
+package component
+
+import (
+	"errors"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"discord-bot-template/internal/i18n"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ============================================
+// Text Input Validators (Modal 輸入驗證)
+// ============================================
+//
+//	modal := component.NewModal().
+//	    CustomID("profile").
+//	    Title("Profile").
+//	    AddInput(component.NewTextInput().CustomID("age").Label("Age").Required().
+//	        Validate(component.IntRange(13, 120))).
+//	    AddInput(component.NewTextInput().CustomID("site").Label("Website").
+//	        Validate(component.URL())).
+//	    Build()
+//
+// Submissions that fail are answered by the bot with the errors and a
+// "Try again" button. Builder validators are kept in memory for ModalValidationTTL
+// under the modal's custom ID (one set per ID, the last Build wins); for modals
+// handled by a registered route, also declare them with commands.WithValidators so
+// they still apply after a restart or once the TTL has passed.
+
+// ModalValidationTTL is how long the validators of a built modal are kept for its submission
+const ModalValidationTTL = time.Hour
+
+// Validator checks a submitted value. The error message is shown to the user
+// (return an i18n.Errorf error to show it in the user's language).
+// Validators are skipped for empty values, use Required() to make an input mandatory.
+type Validator func(value string) error
+
+// Matches requires the value to match a regular expression (message is shown if it doesn't)
+func Matches(pattern, message string) Validator {
+	re := regexp.MustCompile(pattern)
+	return func(value string) error {
+		if !re.MatchString(value) {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// Integer requires a whole number
+func Integer() Validator {
+	return func(value string) error {
+		if _, err := strconv.Atoi(strings.TrimSpace(value)); err != nil {
+			return i18n.Errorf("validation.integer")
+		}
+		return nil
+	}
+}
+
+// IntRange requires a whole number between min and max (inclusive)
+func IntRange(min, max int) Validator {
+	return func(value string) error {
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || n < min || n > max {
+			return i18n.Errorf("validation.int_range", min, max)
+		}
+		return nil
+	}
+}
+
+// URL requires an http(s) link
+func URL() Validator {
+	return func(value string) error {
+		u, err := url.ParseRequestURI(strings.TrimSpace(value))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return i18n.Errorf("validation.url")
+		}
+		return nil
+	}
+}
+
+// Email requires an email address
+func Email() Validator {
+	return func(value string) error {
+		addr, err := mail.ParseAddress(strings.TrimSpace(value))
+		if err != nil || addr.Name != "" {
+			return i18n.Errorf("validation.email")
+		}
+		return nil
+	}
+}
+
+// Date requires a date in the given layout, e.g. component.Date("2006-01-02")
+func Date(layout string) Validator {
+	return func(value string) error {
+		if _, err := time.Parse(layout, strings.TrimSpace(value)); err != nil {
+			return i18n.Errorf("validation.date", layout)
+		}
+		return nil
+	}
+}
+
+// OneOf requires one of the given values (case-insensitive)
+func OneOf(values ...string) Validator {
+	return func(value string) error {
+		for _, v := range values {
+			if strings.EqualFold(strings.TrimSpace(value), v) {
+				return nil
+			}
+		}
+		return i18n.Errorf("validation.one_of", strings.Join(values, ", "))
+	}
+}
+
+// ============================================
+// Modal Validation
+// ============================================
+
+// FieldError is a failed validation of one text input
+type FieldError struct {
+	InputID string
+	Label   string
+	Err     error
+}
+
+func (e FieldError) Error() string {
+	return e.Label + ": " + e.Err.Error()
+}
+
+// modalSpec is what ModalBuilder.Build remembers about a modal with validators
+type modalSpec struct {
+	modal      *discordgo.InteractionResponse
+	validators map[string][]Validator
+	expires    time.Time
+}
+
+var modalSpecs = struct {
+	sync.Mutex
+	byID map[string]*modalSpec
+}{byID: make(map[string]*modalSpec)}
+
+func registerModalSpec(spec *modalSpec) {
+	modalSpecs.Lock()
+	defer modalSpecs.Unlock()
+
+	now := time.Now()
+	for id, s := range modalSpecs.byID {
+		if now.After(s.expires) {
+			delete(modalSpecs.byID, id)
+		}
+	}
+	spec.expires = now.Add(ModalValidationTTL)
+	modalSpecs.byID[spec.modal.Data.CustomID] = spec
+}
+
+func lookupModalSpec(customID string) *modalSpec {
+	modalSpecs.Lock()
+	defer modalSpecs.Unlock()
+	spec := modalSpecs.byID[customID]
+	if spec == nil || time.Now().After(spec.expires) {
+		return nil
+	}
+	return spec
+}
+
+// InputRule holds the validators of one text input, declared when a modal handler
+// is registered (see commands.WithValidators). Unlike the validators given to a
+// ModalBuilder they don't depend on the modal having been built by this process.
+type InputRule struct {
+	Label      string // Shown in the error message (defaults to the input's custom ID)
+	Validators []Validator
+}
+
+// ValidateModal runs the validators given to the modal's builder, plus the rules
+// registered with its handler, against a submission. It returns nil if everything
+// is valid or there are no validators.
+func ValidateModal(data discordgo.ModalSubmitInteractionData, rules map[string]InputRule) []FieldError {
+	spec := lookupModalSpec(data.CustomID)
+
+	labels := make(map[string]string)
+	if spec != nil {
+		for _, input := range modalInputs(spec.modal) {
+			labels[input.CustomID] = input.Label
+		}
+	}
+
+	var errs []FieldError
+	for _, row := range data.Components {
+		actionRow, ok := row.(*discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+		for _, comp := range actionRow.Components {
+			input, ok := comp.(*discordgo.TextInput)
+			if !ok || input.Value == "" {
+				continue
+			}
+
+			rule := rules[input.CustomID]
+			var validators []Validator
+			if spec != nil {
+				validators = append(validators, spec.validators[input.CustomID]...)
+			}
+			validators = append(validators, rule.Validators...)
+
+			label := labels[input.CustomID]
+			if label == "" {
+				label = rule.Label
+			}
+			if label == "" {
+				label = input.CustomID
+			}
+
+			for _, validate := range validators {
+				if err := validate(input.Value); err != nil {
+					errs = append(errs, FieldError{InputID: input.CustomID, Label: label, Err: err})
+					break
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// CanRetryModal reports whether RetryModal can currently rebuild the modal
+// (it was built with validators in this process and has not expired)
+func CanRetryModal(customID string) bool {
+	return lookupModalSpec(customID) != nil
+}
+
+// RetryModal rebuilds a modal with validators, prefilled with the user's previous values.
+// It returns nil if the modal is unknown or expired.
+func RetryModal(customID string, values map[string]string) *discordgo.InteractionResponse {
+	spec := lookupModalSpec(customID)
+	if spec == nil {
+		return nil
+	}
+
+	modal := NewModal().
+		CustomID(spec.modal.Data.CustomID).
+		Title(spec.modal.Data.Title)
+	for _, input := range modalInputs(spec.modal) {
+		if value, ok := values[input.CustomID]; ok {
+			input.Value = value
+		}
+		modal.AddTextInput(input)
+	}
+	// Keep the validators for the new submission
+	modal.validators = spec.validators
+	return modal.Build()
+}
+
+// modalInputs returns copies of a modal's text inputs in order
+func modalInputs(modal *discordgo.InteractionResponse) []discordgo.TextInput {
+	var inputs []discordgo.TextInput
+	for _, row := range modal.Data.Components {
+		var comps []discordgo.MessageComponent
+		switch actionRow := row.(type) {
+		case discordgo.ActionsRow:
+			comps = actionRow.Components
+		case *discordgo.ActionsRow:
+			comps = actionRow.Components
+		}
+		for _, comp := range comps {
+			switch input := comp.(type) {
+			case discordgo.TextInput:
+				inputs = append(inputs, input)
+			case *discordgo.TextInput:
+				inputs = append(inputs, *input)
+			}
+		}
+	}
+	return inputs
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"context"
+	"time"
 
 	"github.com/sethvargo/go-envconfig"
 )
@@ -12,6 +13,18 @@ type Config struct {
 	GuildID  string   `env:"GUILD_ID"`         // Optional: for testing commands in specific guild
 	OwnerIDs []string `env:"BOT_OWNER_IDS"`    // Bot owner Discord IDs (comma-separated)
 	AdminIDs []string `env:"BOT_ADMIN_IDS"`    // Bot admin Discord IDs (comma-separated)
+
+	PermissionsFile string        `env:"PERMISSIONS_FILE, default=data/permissions.json"` // Per-guild permission grants (set to empty, "PERMISSIONS_FILE=", for memory only)
+	AutoDeferAfter  time.Duration `env:"AUTO_DEFER_AFTER, default=2s"`                    // Defer interactions not answered within this time (0 = disabled)
+
+	StateBackend string `env:"STATE_BACKEND, default=memory"`     // Component state store: memory or bolt
+	StateFile    string `env:"STATE_FILE, default=data/state.db"` // BoltDB file for the bolt backend
+
+	TemplatesDir    string        `env:"TEMPLATES_DIR, default=templates"` // Embed templates (*.yaml, *.json)
+	TemplatesReload time.Duration `env:"TEMPLATES_RELOAD, default=5s"`     // Check for changed templates this often (0 = disabled)
+
+	LocalesDir    string `env:"LOCALES_DIR, default=locales"`     // Message catalogs overriding the built-in ones (*.yaml)
+	DefaultLocale string `env:"DEFAULT_LOCALE, default=en-US"` // Fallback locale for missing messages
 }
 
 // Load returns configuration from environment variables
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+func TestPermissionsFile(t *testing.T) {
+	t.Setenv("DISCORD_TOKEN", "token")
+
+	// Unset: the default file
+	t.Setenv("PERMISSIONS_FILE", "")
+	os.Unsetenv("PERMISSIONS_FILE")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.PermissionsFile != "data/permissions.json" {
+		t.Errorf("unset PERMISSIONS_FILE = %q, want the default", cfg.PermissionsFile)
+	}
+
+	// Set to empty: memory only
+	t.Setenv("PERMISSIONS_FILE", "")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.PermissionsFile != "" {
+		t.Errorf("empty PERMISSIONS_FILE = %q, want memory only", cfg.PermissionsFile)
+	}
+}
@@ -9,7 +9,9 @@ import (
 
 // Builder provides a fluent interface for creating Discord embeds
 type Builder struct {
-	embed *discordgo.MessageEmbed
+	embed    *discordgo.MessageEmbed
+	truncate bool // Trim to Discord's limits in Build (see Truncate)
+	sanitize bool // Escape texts set from now on (see Sanitize)
 }
 
 // New creates a new embed builder
@@ -21,13 +23,13 @@ func New() *Builder {
 
 // Title sets the embed title
 func (b *Builder) Title(title string) *Builder {
-	b.embed.Title = title
+	b.embed.Title = b.text(title)
 	return b
 }
 
 // Description sets the embed description
 func (b *Builder) Description(description string) *Builder {
-	b.embed.Description = description
+	b.embed.Description = b.text(description)
 	return b
 }
 
@@ -58,7 +60,7 @@ func (b *Builder) TimestampCustom(t time.Time) *Builder {
 // Footer sets the embed footer
 func (b *Builder) Footer(text string, iconURL string) *Builder {
 	b.embed.Footer = &discordgo.MessageEmbedFooter{
-		Text:    text,
+		Text:    b.text(text),
 		IconURL: iconURL,
 	}
 	return b
@@ -67,7 +69,7 @@ func (b *Builder) Footer(text string, iconURL string) *Builder {
 // FooterText sets just the footer text
 func (b *Builder) FooterText(text string) *Builder {
 	b.embed.Footer = &discordgo.MessageEmbedFooter{
-		Text: text,
+		Text: b.text(text),
 	}
 	return b
 }
@@ -75,7 +77,7 @@ func (b *Builder) FooterText(text string) *Builder {
 // Author sets the embed author
 func (b *Builder) Author(name, url, iconURL string) *Builder {
 	b.embed.Author = &discordgo.MessageEmbedAuthor{
-		Name:    name,
+		Name:    b.text(name),
 		URL:     url,
 		IconURL: iconURL,
 	}
@@ -85,7 +87,7 @@ func (b *Builder) Author(name, url, iconURL string) *Builder {
 // AuthorName sets just the author name
 func (b *Builder) AuthorName(name string) *Builder {
 	b.embed.Author = &discordgo.MessageEmbedAuthor{
-		Name: name,
+		Name: b.text(name),
 	}
 	return b
 }
@@ -109,8 +111,8 @@ func (b *Builder) Image(url string) *Builder {
 // Field adds a field to the embed
 func (b *Builder) Field(name, value string, inline bool) *Builder {
 	b.embed.Fields = append(b.embed.Fields, &discordgo.MessageEmbedField{
-		Name:   name,
-		Value:  value,
+		Name:   b.text(name),
+		Value:  b.text(value),
 		Inline: inline,
 	})
 	return b
@@ -128,18 +130,27 @@ func (b *Builder) BlockField(name, value string) *Builder {
 
 // Fields adds multiple fields at once
 func (b *Builder) Fields(fields ...*discordgo.MessageEmbedField) *Builder {
+	if b.sanitize {
+		for _, field := range fields {
+			b.Field(field.Name, field.Value, field.Inline)
+		}
+		return b
+	}
 	b.embed.Fields = append(b.embed.Fields, fields...)
 	return b
 }
 
-// Build returns the constructed embed
+// Build returns the constructed embed (trimmed to Discord's limits if Truncate was called)
 func (b *Builder) Build() *discordgo.MessageEmbed {
+	if b.truncate {
+		TruncateEmbed(b.embed)
+	}
 	return b.embed
 }
 
 // BuildSlice returns the embed as a slice (useful for InteractionResponse)
 func (b *Builder) BuildSlice() []*discordgo.MessageEmbed {
-	return []*discordgo.MessageEmbed{b.embed}
+	return []*discordgo.MessageEmbed{b.Build()}
 }
 
 // ============================================
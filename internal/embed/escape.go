@@ -0,0 +1,101 @@
+package embed
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// ============================================
+// Escaping (使用者輸入)
+// ============================================
+//
+// User input (modal answers, options, usernames) is shown as typed, so it can't
+// break the surrounding formatting or render as a mention:
+//
+//	embed.Bold(embed.Escape(answers.Title))
+//
+//	embed.New().
+//	    Title("Feedback").     // trusted
+//	    Sanitize().
+//	    BlockField("Message", answers.Message) // escaped from here on
+//
+// Escaping doesn't work inside code (InlineCode / CodeBlock), which shows text as-is anyway.
+
+// zeroWidthSpace breaks mentions without changing how the text looks
+const zeroWidthSpace = "\u200b"
+
+// markdownInline are characters with a meaning anywhere in a line (emphasis, code, spoilers, masked links)
+const markdownInline = "\\*_~`|[]"
+
+var (
+	// Line prefixes: quotes, headings, subtext and lists ("> ", "# ", "-# ", "- ", "1. ")
+	markdownLineStart = regexp.MustCompile(`^\s*([>#+-]|\d+\.)`)
+
+	everyoneMention = regexp.MustCompile(`@(everyone|here)`)
+	idMention       = regexp.MustCompile(`<(@[!&]?|#|/)`)
+)
+
+// Escape escapes markdown and mentions (see EscapeMarkdown and EscapeMentions)
+func Escape(text string) string {
+	return EscapeMentions(EscapeMarkdown(text))
+}
+
+// EscapeMarkdown backslash-escapes Discord markdown so text is shown literally
+func EscapeMarkdown(text string) string {
+	lines := strings.Split(text, "\n")
+	for idx, line := range lines {
+		var b strings.Builder
+		for _, r := range line {
+			if strings.ContainsRune(markdownInline, r) {
+				b.WriteByte('\\')
+			}
+			b.WriteRune(r)
+		}
+		lines[idx] = markdownLineStart.ReplaceAllStringFunc(b.String(), escapeLineStart)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// escapeLineStart escapes a line prefix: "> " → "\> ", "1. " → "1\. "
+func escapeLineStart(prefix string) string {
+	if strings.HasSuffix(prefix, ".") {
+		return strings.TrimSuffix(prefix, ".") + `\.`
+	}
+	trimmed := strings.TrimLeftFunc(prefix, unicode.IsSpace)
+	return prefix[:len(prefix)-len(trimmed)] + `\` + trimmed
+}
+
+// EscapeMentions breaks @everyone / @here and user, role, channel and command
+// mentions (<@id>, <@&id>, <#id>, </name:id>) so they render as plain text.
+// Embeds never ping, but mentions in them still render; in message content
+// AllowedMentions decides what pings (see commands.DefaultAllowedMentions).
+func EscapeMentions(text string) string {
+	text = everyoneMention.ReplaceAllString(text, "@"+zeroWidthSpace+"$1")
+	return idMention.ReplaceAllString(text, "<"+zeroWidthSpace+"$1")
+}
+
+// ============================================
+// Sanitize Mode
+// ============================================
+
+// Sanitize escapes (see Escape) the title, description, field, footer and author
+// texts set after this call. URLs are not changed.
+func (b *Builder) Sanitize() *Builder {
+	b.sanitize = true
+	return b
+}
+
+// Trusted stops escaping texts set after this call (see Sanitize)
+func (b *Builder) Trusted() *Builder {
+	b.sanitize = false
+	return b
+}
+
+// text returns a text to set on the embed, escaped in sanitize mode
+func (b *Builder) text(s string) string {
+	if b.sanitize {
+		return Escape(s)
+	}
+	return s
+}
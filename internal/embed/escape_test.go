@@ -0,0 +1,90 @@
+package embed
+
+import "testing"
+
+func TestEscapeMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"plain", "hello world", "hello world"},
+		{"multibyte", "你好，世界", "你好，世界"},
+		{"bold", "**bold**", `\*\*bold\*\*`},
+		{"underline and italics", "__u__ _i_", `\_\_u\_\_ \_i\_`},
+		{"strikethrough", "~~no~~", `\~\~no\~\~`},
+		{"code", "`code`", "\\`code\\`"},
+		{"spoiler", "||secret||", `\|\|secret\|\|`},
+		{"masked link", "[click](https://example.com)", `\[click\](https://example.com)`},
+		{"backslash", `a\b`, `a\\b`},
+		{"quote", "> quoted", `\> quoted`},
+		{"heading", "# big", `\# big`},
+		{"subtext", "-# small", `\-# small`},
+		{"list", "- item", `\- item`},
+		{"ordered list", "1. item", `1\. item`},
+		{"indented", "  > quoted", `  \> quoted`},
+		{"only at line start", "a > b - c 1. d", "a > b - c 1. d"},
+		{"every line", "one\n> two\n# three", "one\n\\> two\n\\# three"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EscapeMarkdown(tt.text); got != tt.want {
+				t.Errorf("EscapeMarkdown(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"plain", "hello", "hello"},
+		{"everyone", "@everyone hi", "@\u200beveryone hi"},
+		{"here", "hey @here", "hey @\u200bhere"},
+		{"user", "<@123>", "<\u200b@123>"},
+		{"nickname", "<@!123>", "<\u200b@!123>"},
+		{"role", "<@&456>", "<\u200b@&456>"},
+		{"channel", "<#789>", "<\u200b#789>"},
+		{"command", "</ticket open:1>", "<\u200b/ticket open:1>"},
+		{"email", "me@example.com", "me@example.com"},
+		{"link", "<https://example.com>", "<https://example.com>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EscapeMentions(tt.text); got != tt.want {
+				t.Errorf("EscapeMentions(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	e := New().
+		Title("**Trusted**").
+		Sanitize().
+		Description("**@everyone**").
+		BlockField("_name_", "<@123>").
+		Trusted().
+		FooterText("**Footer**").
+		Build()
+
+	checks := []struct {
+		part, got, want string
+	}{
+		{"title", e.Title, "**Trusted**"},
+		{"description", e.Description, "\\*\\*@\u200beveryone\\*\\*"},
+		{"field name", e.Fields[0].Name, `\_name\_`},
+		{"field value", e.Fields[0].Value, "<\u200b@123>"},
+		{"footer", e.Footer.Text, "**Footer**"},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s = %q, want %q", c.part, c.got, c.want)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package embed
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ============================================
+// Embed Limits (Discord 限制)
+// ============================================
+
+// Discord embed limits, counted in characters
+const (
+	MaxTitleLength       = 256
+	MaxDescriptionLength = 4096
+	MaxFields            = 25
+	MaxFieldNameLength   = 256
+	MaxFieldValueLength  = 1024
+	MaxFooterLength      = 2048
+	MaxAuthorNameLength  = 256
+	MaxTotalLength       = 6000 // Title + description + fields + footer + author name
+	MaxEmbedsPerMessage  = 10
+)
+
+// Ellipsis is appended to text cut by the truncating mode
+const Ellipsis = "…"
+
+// LimitError reports a part of an embed that is longer than Discord allows
+type LimitError struct {
+	Part   string // e.g. "title", "field 3 value"
+	Length int
+	Max    int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("embed %s is %d characters (max %d)", e.Part, e.Length, e.Max)
+}
+
+// Validate checks the embed against Discord's limits (see Validate)
+func (b *Builder) Validate() error {
+	return Validate(b.embed)
+}
+
+// Truncate makes Build trim text to Discord's limits with an ellipsis and drop
+// fields that don't fit, instead of leaving it to Validate (or Discord) to reject
+func (b *Builder) Truncate() *Builder {
+	b.truncate = true
+	return b
+}
+
+// Validate checks every Discord embed limit (lengths, field count, total size, URL schemes)
+// and returns all problems found, or nil if Discord will accept the embed
+func Validate(e *discordgo.MessageEmbed) error {
+	var errs []error
+	check := func(part, text string, max int) {
+		if n := length(text); n > max {
+			errs = append(errs, &LimitError{Part: part, Length: n, Max: max})
+		}
+	}
+
+	check("title", e.Title, MaxTitleLength)
+	check("description", e.Description, MaxDescriptionLength)
+
+	if len(e.Fields) > MaxFields {
+		errs = append(errs, fmt.Errorf("embed has %d fields (max %d)", len(e.Fields), MaxFields))
+	}
+	for idx, field := range e.Fields {
+		if field.Name == "" || field.Value == "" {
+			errs = append(errs, fmt.Errorf("embed field %d needs a name and a value", idx+1))
+		}
+		check(fmt.Sprintf("field %d name", idx+1), field.Name, MaxFieldNameLength)
+		check(fmt.Sprintf("field %d value", idx+1), field.Value, MaxFieldValueLength)
+	}
+
+	if e.Footer != nil {
+		check("footer", e.Footer.Text, MaxFooterLength)
+		errs = append(errs, checkURL("footer icon", e.Footer.IconURL, true))
+	}
+	if e.Author != nil {
+		check("author name", e.Author.Name, MaxAuthorNameLength)
+		errs = append(errs, checkURL("author URL", e.Author.URL, false))
+		errs = append(errs, checkURL("author icon", e.Author.IconURL, true))
+	}
+
+	if n := TotalLength(e); n > MaxTotalLength {
+		errs = append(errs, &LimitError{Part: "total", Length: n, Max: MaxTotalLength})
+	}
+
+	errs = append(errs, checkURL("URL", e.URL, false))
+	if e.Thumbnail != nil {
+		errs = append(errs, checkURL("thumbnail", e.Thumbnail.URL, true))
+	}
+	if e.Image != nil {
+		errs = append(errs, checkURL("image", e.Image.URL, true))
+	}
+
+	return errors.Join(errs...)
+}
+
+// TotalLength returns the characters Discord counts towards the 6000 limit
+func TotalLength(e *discordgo.MessageEmbed) int {
+	n := length(e.Title) + length(e.Description)
+	for _, field := range e.Fields {
+		n += length(field.Name) + length(field.Value)
+	}
+	if e.Footer != nil {
+		n += length(e.Footer.Text)
+	}
+	if e.Author != nil {
+		n += length(e.Author.Name)
+	}
+	return n
+}
+
+// TruncateEmbed trims an embed in place so it fits Discord's length limits.
+// Text is cut with an ellipsis; fields that don't fit are dropped from the end.
+func TruncateEmbed(e *discordgo.MessageEmbed) *discordgo.MessageEmbed {
+	e.Title = TruncateText(e.Title, MaxTitleLength)
+	e.Description = TruncateText(e.Description, MaxDescriptionLength)
+
+	if len(e.Fields) > MaxFields {
+		e.Fields = e.Fields[:MaxFields]
+	}
+	for _, field := range e.Fields {
+		field.Name = TruncateText(field.Name, MaxFieldNameLength)
+		field.Value = TruncateText(field.Value, MaxFieldValueLength)
+	}
+
+	if e.Footer != nil {
+		e.Footer.Text = TruncateText(e.Footer.Text, MaxFooterLength)
+	}
+	if e.Author != nil {
+		e.Author.Name = TruncateText(e.Author.Name, MaxAuthorNameLength)
+	}
+
+	// Over the total: drop fields from the end, then shorten the description
+	for len(e.Fields) > 0 && TotalLength(e) > MaxTotalLength {
+		e.Fields = e.Fields[:len(e.Fields)-1]
+	}
+	if excess := TotalLength(e) - MaxTotalLength; excess > 0 {
+		e.Description = TruncateText(e.Description, max(length(e.Description)-excess, 0))
+	}
+	return e
+}
+
+// TruncateText cuts text to at most max characters, ending with an ellipsis if it was cut
+func TruncateText(text string, max int) string {
+	if length(text) <= max {
+		return text
+	}
+	if max <= 0 {
+		return ""
+	}
+
+	runes := []rune(text)
+	return string(runes[:max-length(Ellipsis)]) + Ellipsis
+}
+
+// length counts characters the way Discord does (not bytes)
+func length(text string) int {
+	return utf8.RuneCountInString(text)
+}
+
+// checkURL accepts empty, http(s) and, for images, attachment:// URLs
+func checkURL(part, raw string, image bool) error {
+	if raw == "" {
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err == nil {
+		switch u.Scheme {
+		case "http", "https":
+			return nil
+		case "attachment":
+			if image {
+				return nil
+			}
+		}
+	}
+	if image {
+		return fmt.Errorf("embed %s URL %q must be http(s) or attachment://", part, raw)
+	}
+	return fmt.Errorf("embed %s %q must be http(s)", part, raw)
+}
@@ -0,0 +1,148 @@
+package embed
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		max  int
+		want string
+	}{
+		{"fits", "hello", 10, "hello"},
+		{"exactly max", "hello", 5, "hello"},
+		{"cut", "hello world", 8, "hello w…"},
+		{"multibyte", "你好世界和平", 4, "你好世…"},
+		{"emoji", "😀😀😀😀", 3, "😀😀…"},
+		{"zero", "hello", 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TruncateText(tt.text, tt.max); got != tt.want {
+				t.Errorf("TruncateText(%q, %d) = %q, want %q", tt.text, tt.max, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTotalLength(t *testing.T) {
+	e := &discordgo.MessageEmbed{
+		Title:       "標題",
+		Description: "説明文",
+		Fields:      []*discordgo.MessageEmbedField{{Name: "名", Value: "值"}},
+		Footer:      &discordgo.MessageEmbedFooter{Text: "😀"},
+		Author:      &discordgo.MessageEmbedAuthor{Name: "ab"},
+	}
+	if got := TotalLength(e); got != 10 {
+		t.Errorf("TotalLength = %d, want 10 (characters, not bytes)", got)
+	}
+}
+
+func TestTruncateEmbed(t *testing.T) {
+	t.Run("part limits", func(t *testing.T) {
+		e := &discordgo.MessageEmbed{
+			Title:  strings.Repeat("界", MaxTitleLength+10),
+			Footer: &discordgo.MessageEmbedFooter{Text: strings.Repeat("a", MaxFooterLength+1)},
+		}
+		for idx := 0; idx < MaxFields+5; idx++ {
+			e.Fields = append(e.Fields, &discordgo.MessageEmbedField{Name: "n", Value: "v"})
+		}
+
+		TruncateEmbed(e)
+		if length(e.Title) != MaxTitleLength || !strings.HasSuffix(e.Title, Ellipsis) {
+			t.Errorf("title is %d characters, want %d ending with an ellipsis", length(e.Title), MaxTitleLength)
+		}
+		if length(e.Footer.Text) != MaxFooterLength {
+			t.Errorf("footer is %d characters, want %d", length(e.Footer.Text), MaxFooterLength)
+		}
+		if len(e.Fields) != MaxFields {
+			t.Errorf("%d fields, want %d", len(e.Fields), MaxFields)
+		}
+	})
+
+	t.Run("total drops fields first", func(t *testing.T) {
+		e := &discordgo.MessageEmbed{Description: strings.Repeat("a", 4000)}
+		for idx := 0; idx < 5; idx++ {
+			e.Fields = append(e.Fields, &discordgo.MessageEmbedField{
+				Name:  strings.Repeat("n", 10),
+				Value: strings.Repeat("v", 1000),
+			})
+		}
+
+		TruncateEmbed(e)
+		if len(e.Fields) != 1 || length(e.Description) != 4000 {
+			t.Errorf("kept %d fields and %d description characters, want 1 and 4000", len(e.Fields), length(e.Description))
+		}
+	})
+
+	t.Run("total shortens the description", func(t *testing.T) {
+		e := &discordgo.MessageEmbed{
+			Title:       strings.Repeat("t", MaxTitleLength),
+			Description: strings.Repeat("d", MaxDescriptionLength),
+			Footer:      &discordgo.MessageEmbedFooter{Text: strings.Repeat("f", MaxFooterLength)},
+		}
+
+		TruncateEmbed(e)
+		if n := TotalLength(e); n != MaxTotalLength {
+			t.Errorf("total is %d characters, want %d", n, MaxTotalLength)
+		}
+		if !strings.HasSuffix(e.Description, Ellipsis) {
+			t.Errorf("shortened description doesn't end with an ellipsis")
+		}
+		if err := Validate(e); err != nil {
+			t.Errorf("Validate after TruncateEmbed: %v", err)
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	valid := &discordgo.MessageEmbed{
+		Title:     "Title",
+		Thumbnail: &discordgo.MessageEmbedThumbnail{URL: "attachment://logo.png"},
+		Fields:    []*discordgo.MessageEmbedField{{Name: "n", Value: "v"}},
+	}
+	if err := Validate(valid); err != nil {
+		t.Errorf("Validate(valid) = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		e    *discordgo.MessageEmbed
+		part string // Part of the expected LimitError, empty for other errors
+	}{
+		{"title", &discordgo.MessageEmbed{Title: strings.Repeat("界", MaxTitleLength+1)}, "title"},
+		{"field value", &discordgo.MessageEmbed{Fields: []*discordgo.MessageEmbedField{
+			{Name: "n", Value: strings.Repeat("v", MaxFieldValueLength+1)},
+		}}, "field 1 value"},
+		{"total", &discordgo.MessageEmbed{
+			Description: strings.Repeat("d", MaxDescriptionLength),
+			Footer:      &discordgo.MessageEmbedFooter{Text: strings.Repeat("f", MaxFooterLength)},
+		}, "total"},
+		{"empty field", &discordgo.MessageEmbed{Fields: []*discordgo.MessageEmbedField{{Name: "n"}}}, ""},
+		{"URL scheme", &discordgo.MessageEmbed{URL: "javascript:alert(1)"}, ""},
+		{"attachment outside images", &discordgo.MessageEmbed{URL: "attachment://logo.png"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.e)
+			if err == nil {
+				t.Fatalf("Validate returned nil")
+			}
+			if tt.part == "" {
+				return
+			}
+			var limitErr *LimitError
+			if !errors.As(err, &limitErr) || limitErr.Part != tt.part {
+				t.Errorf("Validate = %v, want a LimitError for %q", err, tt.part)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package embed
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ============================================
+// Splitting (長內容分成多個 embed)
+// ============================================
+//
+//	pages := embed.New().
+//	    Title("Members").
+//	    Color(embed.ColorInfo).
+//	    Description(strings.Join(lines, "\n")).
+//	    Split()
+//
+//	component.NewPaginator(pages).Send(ctx)  // one page per embed
+//	ctx.ReplyEmbeds(pages, false)            // or as many messages as needed
+
+// pageMarkerReserve is room kept for the " (12/34)" marker added by Split
+const pageMarkerReserve = 16
+
+// Split spreads the description and fields over as many embeds as needed to respect
+// Discord's limits. The description is cut on line boundaries (then words), fields are
+// never cut. Every embed keeps the title, color, URL, author, thumbnail and footer, and
+// gets a "(1/3)" marker after the title (or footer) when there is more than one.
+// The image and timestamp are kept on the last embed only.
+func (b *Builder) Split() []*discordgo.MessageEmbed {
+	return Split(b.embed)
+}
+
+// Split spreads an embed's description and fields over several embeds (see Builder.Split)
+func Split(e *discordgo.MessageEmbed) []*discordgo.MessageEmbed {
+	// Room left for content once the repeated parts are counted
+	budget := MaxTotalLength - length(e.Title) - pageMarkerReserve
+	if e.Footer != nil {
+		budget -= length(e.Footer.Text)
+	}
+	if e.Author != nil {
+		budget -= length(e.Author.Name)
+	}
+	budget = max(budget, MaxFieldNameLength+MaxFieldValueLength)
+
+	type page struct {
+		description string
+		fields      []*discordgo.MessageEmbedField
+		used        int
+	}
+
+	var pages []*page
+	for _, chunk := range SplitText(e.Description, min(MaxDescriptionLength, budget)) {
+		pages = append(pages, &page{description: chunk, used: length(chunk)})
+	}
+	if len(pages) == 0 {
+		pages = append(pages, &page{})
+	}
+
+	for _, field := range e.Fields {
+		size := length(field.Name) + length(field.Value)
+		last := pages[len(pages)-1]
+		if len(last.fields) >= MaxFields || last.used+size > budget {
+			last = &page{}
+			pages = append(pages, last)
+		}
+		last.fields = append(last.fields, field)
+		last.used += size
+	}
+
+	embeds := make([]*discordgo.MessageEmbed, len(pages))
+	for idx, p := range pages {
+		out := &discordgo.MessageEmbed{
+			Type:        e.Type,
+			Title:       e.Title,
+			Description: p.description,
+			URL:         e.URL,
+			Color:       e.Color,
+			Fields:      p.fields,
+			Thumbnail:   e.Thumbnail,
+			Provider:    e.Provider,
+		}
+		if e.Author != nil {
+			author := *e.Author
+			out.Author = &author
+		}
+		if e.Footer != nil {
+			footer := *e.Footer
+			out.Footer = &footer
+		}
+		if idx == len(pages)-1 {
+			out.Image = e.Image
+			out.Timestamp = e.Timestamp
+		}
+
+		if len(pages) > 1 {
+			addPageMarker(out, idx+1, len(pages))
+		}
+		embeds[idx] = out
+	}
+	return embeds
+}
+
+// addPageMarker appends "(n/total)" to the title, or the footer if there is no title
+func addPageMarker(e *discordgo.MessageEmbed, n, total int) {
+	marker := fmt.Sprintf("(%d/%d)", n, total)
+	switch {
+	case e.Title != "":
+		e.Title = TruncateText(e.Title, MaxTitleLength-len(marker)-1) + " " + marker
+	case e.Footer != nil && e.Footer.Text != "":
+		e.Footer.Text += " • " + marker
+	case e.Footer != nil:
+		e.Footer.Text = marker
+	default:
+		e.Footer = &discordgo.MessageEmbedFooter{Text: marker}
+	}
+}
+
+// SplitText cuts text into chunks of at most max characters, preferring line breaks,
+// then spaces, and only cutting inside a word if a single word is too long
+func SplitText(text string, max int) []string {
+	if text == "" {
+		return nil
+	}
+	if max <= 0 {
+		return []string{text}
+	}
+
+	var chunks []string
+	var current strings.Builder
+	flush := func() {
+		if chunk := strings.TrimRight(current.String(), "\n"); chunk != "" {
+			chunks = append(chunks, chunk)
+		}
+		current.Reset()
+	}
+
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if length(current.String())+length(line) <= max {
+			current.WriteString(line)
+			continue
+		}
+		flush()
+
+		for length(line) > max {
+			cut := cutPoint(line, max)
+			chunks = append(chunks, strings.TrimRight(line[:cut], " \n"))
+			line = strings.TrimLeft(line[cut:], " ")
+		}
+		current.WriteString(line)
+	}
+	flush()
+
+	return chunks
+}
+
+// cutPoint returns the byte index to cut a line of more than max characters at:
+// after the last space within max characters (or right after them), or exactly at max characters
+func cutPoint(line string, max int) int {
+	end, n := len(line), 0
+	for idx := range line {
+		if n == max {
+			end = idx
+			break
+		}
+		n++
+	}
+
+	if end < len(line) && (line[end] == ' ' || line[end] == '\n') {
+		return end + 1
+	}
+	if space := strings.LastIndex(line[:end], " "); space > 0 {
+		return space + 1
+	}
+	return end
+}
+
+// ============================================
+// Batching (多個 embed 分成多則訊息)
+// ============================================
+
+// Batch groups embeds into messages of at most 10 embeds and 6000 characters each.
+// Each embed must already fit on its own (see Split).
+func Batch(embeds []*discordgo.MessageEmbed) [][]*discordgo.MessageEmbed {
+	var batches [][]*discordgo.MessageEmbed
+	var current []*discordgo.MessageEmbed
+	size := 0
+
+	for _, e := range embeds {
+		n := TotalLength(e)
+		if len(current) > 0 && (len(current) >= MaxEmbedsPerMessage || size+n > MaxTotalLength) {
+			batches = append(batches, current)
+			current, size = nil, 0
+		}
+		current = append(current, e)
+		size += n
+	}
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+	return batches
+}
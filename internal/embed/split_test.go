@@ -0,0 +1,172 @@
+package embed
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestSplitText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		max  int
+		want []string
+	}{
+		{"empty", "", 10, nil},
+		{"fits", "hello", 10, []string{"hello"}},
+		{"no limit", "hello world", 0, []string{"hello world"}},
+		{"line boundaries", "one\ntwo\nthree", 8, []string{"one\ntwo", "three"}},
+		{"words", "hello world foo", 11, []string{"hello world", "foo"}},
+		{"space before max", "hello world foo", 8, []string{"hello", "world", "foo"}},
+		{"word longer than max", "abcdefghij", 4, []string{"abcd", "efgh", "ij"}},
+		{"long word after short one", "hi abcdefgh", 4, []string{"hi", "abcd", "efgh"}},
+		{"multibyte", "你好世界和平", 4, []string{"你好世界", "和平"}},
+		{"multibyte words", "你好 世界 和平", 5, []string{"你好 世界", "和平"}},
+		{"emoji", "😀😀😀", 2, []string{"😀😀", "😀"}},
+		{"blank lines dropped at cuts", "aaaa\n\nbbbb", 4, []string{"aaaa", "bbbb"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitText(tt.text, tt.max)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitText(%q, %d) = %q, want %q", tt.text, tt.max, got, tt.want)
+			}
+			for _, chunk := range got {
+				if tt.max > 0 && length(chunk) > tt.max {
+					t.Errorf("chunk %q is %d characters (max %d)", chunk, length(chunk), tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestCutPoint(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		max  int
+		want int
+	}{
+		{"last space", "hello world", 8, 6},
+		{"space right after max", "hello world", 5, 6},
+		{"no space", "abcdef", 3, 3},
+		{"leading space only", " abcdef", 3, 3},
+		{"multibyte", "你好 世界", 4, len("你好 ")},
+		{"multibyte no space", "你好世界", 3, len("你好世")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cutPoint(tt.line, tt.max); got != tt.want {
+				t.Errorf("cutPoint(%q, %d) = %d, want %d", tt.line, tt.max, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	lines := make([]string, 3000)
+	for idx := range lines {
+		lines[idx] = fmt.Sprintf("line %04d", idx)
+	}
+	e := &discordgo.MessageEmbed{
+		Title:       "Members",
+		Description: strings.Join(lines, "\n"),
+		Image:       &discordgo.MessageEmbedImage{URL: "https://example.com/a.png"},
+	}
+
+	pages := Split(e)
+	if len(pages) < 2 {
+		t.Fatalf("Split returned %d embeds, want several", len(pages))
+	}
+
+	var got []string
+	for idx, page := range pages {
+		if err := Validate(page); err != nil {
+			t.Errorf("page %d: %v", idx+1, err)
+		}
+		if want := fmt.Sprintf("Members (%d/%d)", idx+1, len(pages)); page.Title != want {
+			t.Errorf("page %d title = %q, want %q", idx+1, page.Title, want)
+		}
+		if last := idx == len(pages)-1; (page.Image != nil) != last {
+			t.Errorf("page %d has image = %v, want it on the last page only", idx+1, page.Image != nil)
+		}
+		got = append(got, strings.Split(page.Description, "\n")...)
+	}
+	if !reflect.DeepEqual(got, lines) {
+		t.Errorf("pages don't contain every line exactly once (%d lines, want %d)", len(got), len(lines))
+	}
+}
+
+func TestSplitFields(t *testing.T) {
+	e := &discordgo.MessageEmbed{Footer: &discordgo.MessageEmbedFooter{Text: "Page"}}
+	for idx := 0; idx < 30; idx++ {
+		e.Fields = append(e.Fields, &discordgo.MessageEmbedField{Name: fmt.Sprint(idx), Value: "value"})
+	}
+
+	pages := Split(e)
+	if len(pages) != 2 || len(pages[0].Fields) != MaxFields || len(pages[1].Fields) != 5 {
+		t.Fatalf("Split spread 30 fields as %d embeds, want 25 + 5", len(pages))
+	}
+	// Without a title the marker goes into the footer, which is copied per page
+	if pages[0].Footer.Text != "Page • (1/2)" || pages[1].Footer.Text != "Page • (2/2)" {
+		t.Errorf("footers = %q, %q", pages[0].Footer.Text, pages[1].Footer.Text)
+	}
+	if e.Footer.Text != "Page" {
+		t.Errorf("Split changed the original footer to %q", e.Footer.Text)
+	}
+}
+
+func TestSplitSinglePage(t *testing.T) {
+	pages := Split(&discordgo.MessageEmbed{Title: "Short", Description: "fits"})
+	if len(pages) != 1 || pages[0].Title != "Short" || pages[0].Footer != nil {
+		t.Errorf("Split of a small embed = %+v, want it unchanged without a marker", pages[0])
+	}
+
+	pages = Split(&discordgo.MessageEmbed{Description: strings.Repeat("word ", 1000)})
+	if len(pages) != 2 || pages[0].Footer == nil || pages[0].Footer.Text != "(1/2)" {
+		t.Errorf("marker without title or footer: got %d pages, footer %+v", len(pages), pages[0].Footer)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	embeds := func(n, size int) []*discordgo.MessageEmbed {
+		out := make([]*discordgo.MessageEmbed, n)
+		for idx := range out {
+			out[idx] = &discordgo.MessageEmbed{Description: strings.Repeat("a", size)}
+		}
+		return out
+	}
+	sizes := func(batches [][]*discordgo.MessageEmbed) []int {
+		out := make([]int, len(batches))
+		for idx, batch := range batches {
+			out[idx] = len(batch)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name   string
+		embeds []*discordgo.MessageEmbed
+		want   []int
+	}{
+		{"none", nil, []int{}},
+		{"10 embeds per message", embeds(23, 10), []int{10, 10, 3}},
+		{"6000 characters per message", embeds(5, 2500), []int{2, 2, 1}},
+		{"exactly 6000", embeds(3, 2000), []int{3}},
+		{"oversized embed alone", embeds(2, 7000), []int{1, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sizes(Batch(tt.embeds)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Batch sizes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,432 @@
+package embed
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"gopkg.in/yaml.v3"
+)
+
+// ============================================
+// Embed Templates (從 YAML / JSON 檔案載入)
+// ============================================
+//
+// Each file in the templates directory is one embed, named after the file
+// (templates/help.yaml → "help"). Files use Discord's embed JSON shape, either
+// the embed itself or a message with a single embed ({"embeds": [{...}]}).
+// Every text and URL is a Go text/template:
+//
+//	title: "Welcome{{ with .Guild }} to {{ .Name }}{{ end }}!"
+//	description: |
+//	  Hi {{ mention .User.ID }}, read the rules in {{ mentionChannel .Data.rules }}.
+//	color: "#5865F2"
+//	fields:
+//	  - name: Members
+//	    value: "{{ with .Guild }}{{ .MemberCount }}{{ end }}"
+//	    inline: true
+//
+// .Guild is nil in DMs, so guard it with {{ with .Guild }}.
+//
+//	b, err := embed.Render("welcome", embed.TemplateData{User: user, Guild: guild, Data: map[string]string{"rules": id}})
+
+// TemplateData is what a template's placeholders can use
+type TemplateData struct {
+	User   *discordgo.User
+	Member *discordgo.Member
+	Guild  *discordgo.Guild
+	Data   interface{} // Anything else the caller passes in
+}
+
+// Template is a parsed embed template
+type Template struct {
+	Name string
+
+	embed *discordgo.MessageEmbed
+	tmpl  *template.Template
+}
+
+// templateFuncs are available in every template
+var templateFuncs = template.FuncMap{
+	"bold":           Bold,
+	"italic":         Italic,
+	"underline":      Underline,
+	"strike":         Strikethrough,
+	"spoiler":        Spoiler,
+	"code":           InlineCode,
+	"quote":          Quote,
+	"mention":        Mention,
+	"mentionRole":    MentionRole,
+	"mentionChannel": MentionChannel,
+	"timestamp":      Timestamp,
+	"relative":       RelativeTime,
+	"now":            time.Now,
+	"upper":          strings.ToUpper,
+	"lower":          strings.ToLower,
+	"escape":         Escape,
+}
+
+// ParseTemplate parses a template from YAML or JSON (JSON is valid YAML).
+// Placeholder syntax and the embed's limits are checked here, so mistakes
+// are reported when the file is loaded rather than when it is used.
+func ParseTemplate(name string, data []byte) (*Template, error) {
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("template %s: %w", name, err)
+	}
+
+	doc, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("template %s: expected an embed object", name)
+	}
+
+	// Message shape: {"embeds": [{...}]}
+	if embeds, ok := doc["embeds"]; ok {
+		list, ok := embeds.([]interface{})
+		if !ok || len(list) != 1 {
+			return nil, fmt.Errorf("template %s: expected exactly one embed in \"embeds\"", name)
+		}
+		if doc, ok = list[0].(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("template %s: expected an embed object", name)
+		}
+	}
+
+	if err := normalizeColor(doc); err != nil {
+		return nil, fmt.Errorf("template %s: %w", name, err)
+	}
+
+	// Decode through JSON so the discordgo field names (icon_url, ...) apply to YAML too
+	buf, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("template %s: %w", name, err)
+	}
+	e := &discordgo.MessageEmbed{}
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(e); err != nil {
+		return nil, fmt.Errorf("template %s: %w", name, err)
+	}
+
+	t := &Template{
+		Name:  name,
+		embed: e,
+		tmpl:  template.New(name).Funcs(templateFuncs).Option("missingkey=zero"),
+	}
+	for _, part := range templateParts(e) {
+		if _, err := t.tmpl.New(part.name).Parse(*part.text); err != nil {
+			return nil, fmt.Errorf("template %s: %w", name, err)
+		}
+	}
+
+	if err := validateTemplate(e); err != nil {
+		return nil, fmt.Errorf("template %s: %w", name, err)
+	}
+	return t, nil
+}
+
+// Render fills in the placeholders and returns a builder for the result
+func (t *Template) Render(data TemplateData) (*Builder, error) {
+	e := cloneEmbed(t.embed)
+	for _, part := range templateParts(e) {
+		var out strings.Builder
+		if err := t.tmpl.ExecuteTemplate(&out, part.name, data); err != nil {
+			return nil, fmt.Errorf("template %s: %w", t.Name, err)
+		}
+		*part.text = out.String()
+	}
+
+	if err := Validate(e); err != nil {
+		return nil, fmt.Errorf("template %s: %w", t.Name, err)
+	}
+	return &Builder{embed: e}, nil
+}
+
+// templatePart is one text of an embed that may contain placeholders
+type templatePart struct {
+	name string
+	text *string
+}
+
+// templateParts lists every text and URL of an embed, in a stable order
+func templateParts(e *discordgo.MessageEmbed) []templatePart {
+	parts := []templatePart{
+		{"title", &e.Title},
+		{"description", &e.Description},
+		{"url", &e.URL},
+		{"timestamp", &e.Timestamp},
+	}
+	for idx, field := range e.Fields {
+		parts = append(parts,
+			templatePart{fmt.Sprintf("field.%d.name", idx), &field.Name},
+			templatePart{fmt.Sprintf("field.%d.value", idx), &field.Value})
+	}
+	if e.Footer != nil {
+		parts = append(parts, templatePart{"footer.text", &e.Footer.Text}, templatePart{"footer.icon_url", &e.Footer.IconURL})
+	}
+	if e.Author != nil {
+		parts = append(parts,
+			templatePart{"author.name", &e.Author.Name},
+			templatePart{"author.url", &e.Author.URL},
+			templatePart{"author.icon_url", &e.Author.IconURL})
+	}
+	if e.Thumbnail != nil {
+		parts = append(parts, templatePart{"thumbnail.url", &e.Thumbnail.URL})
+	}
+	if e.Image != nil {
+		parts = append(parts, templatePart{"image.url", &e.Image.URL})
+	}
+	return parts
+}
+
+// validateTemplate checks the limits of the unrendered embed.
+// URLs with placeholders can only be checked after rendering.
+func validateTemplate(e *discordgo.MessageEmbed) error {
+	check := cloneEmbed(e)
+	for _, part := range templateParts(check) {
+		if strings.HasSuffix(part.name, "url") && strings.Contains(*part.text, "{{") {
+			*part.text = ""
+		}
+	}
+	return Validate(check)
+}
+
+// normalizeColor accepts "#5865F2" / "0x5865F2" strings in addition to Discord's integer colors
+func normalizeColor(doc map[string]interface{}) error {
+	s, ok := doc["color"].(string)
+	if !ok {
+		return nil
+	}
+	s = strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(s), "#"), "0x")
+	n, err := strconv.ParseInt(s, 16, 32)
+	if err != nil {
+		return fmt.Errorf("invalid color %q", doc["color"])
+	}
+	doc["color"] = n
+	return nil
+}
+
+func cloneEmbed(e *discordgo.MessageEmbed) *discordgo.MessageEmbed {
+	c := *e
+	c.Fields = make([]*discordgo.MessageEmbedField, len(e.Fields))
+	for idx, field := range e.Fields {
+		f := *field
+		c.Fields[idx] = &f
+	}
+	if e.Footer != nil {
+		footer := *e.Footer
+		c.Footer = &footer
+	}
+	if e.Author != nil {
+		author := *e.Author
+		c.Author = &author
+	}
+	if e.Thumbnail != nil {
+		thumbnail := *e.Thumbnail
+		c.Thumbnail = &thumbnail
+	}
+	if e.Image != nil {
+		image := *e.Image
+		c.Image = &image
+	}
+	return &c
+}
+
+// ============================================
+// Template Directory (熱重載)
+// ============================================
+
+// ErrTemplateNotFound is returned by Render for unknown template names
+var ErrTemplateNotFound = errors.New("embed template not found")
+
+// TemplateSet holds the templates loaded from a directory
+type TemplateSet struct {
+	dir string
+
+	mu        sync.RWMutex
+	templates map[string]*Template
+	snapshot  string // Names, sizes and modification times of the files at the last load
+}
+
+var defaultTemplates = &TemplateSet{templates: map[string]*Template{}}
+
+// InitTemplates loads the templates used by Render from dir (*.yaml, *.yml, *.json) and,
+// if reload > 0, checks the directory for changes at that interval.
+// A missing directory is not an error: there are simply no templates.
+func InitTemplates(dir string, reload time.Duration) error {
+	if dir == "" {
+		return nil
+	}
+
+	set, err := LoadTemplates(dir)
+	if err != nil {
+		return err
+	}
+	defaultTemplates = set
+
+	if reload > 0 {
+		go set.watch(reload)
+	}
+	return nil
+}
+
+// Render renders a template loaded by InitTemplates
+func Render(name string, data TemplateData) (*Builder, error) {
+	return defaultTemplates.Render(name, data)
+}
+
+// LoadTemplates parses every template file in dir
+func LoadTemplates(dir string) (*TemplateSet, error) {
+	set := &TemplateSet{dir: dir, templates: map[string]*Template{}}
+	if err := set.Reload(); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// Render renders a template of this set
+func (s *TemplateSet) Render(name string, data TemplateData) (*Builder, error) {
+	s.mu.RLock()
+	t, ok := s.templates[name]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTemplateNotFound, name)
+	}
+	return t.Render(data)
+}
+
+// Names returns the names of the loaded templates
+func (s *TemplateSet) Names() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	names := make([]string, 0, len(s.templates))
+	for name := range s.templates {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Reload parses the directory again. If any file is invalid the current templates are kept.
+func (s *TemplateSet) Reload() error {
+	files, snapshot, err := s.files()
+	if err != nil {
+		return err
+	}
+	return s.load(files, snapshot)
+}
+
+// load parses files and replaces the templates if all of them are valid
+func (s *TemplateSet) load(files []string, snapshot string) error {
+
+	templates := make(map[string]*Template, len(files))
+	var errs []error
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		if _, ok := templates[name]; ok {
+			errs = append(errs, fmt.Errorf("template %s: defined by more than one file", name))
+			continue
+		}
+		t, err := ParseTemplate(name, data)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			continue
+		}
+		templates[name] = t
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	s.mu.Lock()
+	s.templates = templates
+	s.snapshot = snapshot
+	s.mu.Unlock()
+	return nil
+}
+
+// files lists the template files and a snapshot of their names, sizes and modification times.
+// Any difference between two snapshots (including an older file restored from a backup) is a change.
+func (s *TemplateSet) files() ([]string, string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	var files []string
+	var snapshot strings.Builder
+	for _, entry := range entries {
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, "", err
+		}
+		fmt.Fprintf(&snapshot, "%s %d %d\n", entry.Name(), info.Size(), info.ModTime().UnixNano())
+		files = append(files, filepath.Join(s.dir, entry.Name()))
+	}
+	return files, snapshot.String(), nil
+}
+
+// reloadIfChanged reloads the templates when a file was added, removed or changed since the last check
+func (s *TemplateSet) reloadIfChanged() (bool, error) {
+	files, snapshot, err := s.files()
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.RLock()
+	changed := snapshot != s.snapshot
+	s.mu.RUnlock()
+	if !changed {
+		return false, nil
+	}
+
+	if err := s.load(files, snapshot); err != nil {
+		// Don't retry until the files change again
+		s.mu.Lock()
+		s.snapshot = snapshot
+		s.mu.Unlock()
+		return false, err
+	}
+	return true, nil
+}
+
+// watch reloads the templates when a file is added, removed or changed
+func (s *TemplateSet) watch(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		reloaded, err := s.reloadIfChanged()
+		if err != nil {
+			log.Printf("Failed to reload embed templates, keeping the previous ones: %v", err)
+			continue
+		}
+		if reloaded {
+			log.Printf("Reloaded %d embed templates from %s", len(s.Names()), s.dir)
+		}
+	}
+}
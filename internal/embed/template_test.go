@@ -0,0 +1,134 @@
+package embed
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestTemplateRender(t *testing.T) {
+	tmpl, err := ParseTemplate("welcome", []byte(`
+title: "Welcome{{ with .Guild }} to {{ .Name }}{{ end }}!"
+description: "Hi {{ mention .User.ID }}, read {{ mentionChannel .Data.rules }}."
+color: "#5865F2"
+fields:
+  - name: Members
+    value: "{{ with .Guild }}{{ .MemberCount }}{{ else }}-{{ end }}"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := &discordgo.User{ID: "1", Username: "alice"}
+	data := map[string]string{"rules": "2"}
+	tests := []struct {
+		name      string
+		guild     *discordgo.Guild
+		wantTitle string
+		wantField string
+	}{
+		{"guild", &discordgo.Guild{Name: "Gophers", MemberCount: 42}, "Welcome to Gophers!", "42"},
+		{"DM", nil, "Welcome!", "-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := tmpl.Render(TemplateData{User: user, Guild: tt.guild, Data: data})
+			if err != nil {
+				t.Fatal(err)
+			}
+			e := b.Build()
+			if e.Title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", e.Title, tt.wantTitle)
+			}
+			if want := "Hi <@1>, read <#2>."; e.Description != want {
+				t.Errorf("description = %q, want %q", e.Description, want)
+			}
+			if e.Fields[0].Value != tt.wantField {
+				t.Errorf("field = %q, want %q", e.Fields[0].Value, tt.wantField)
+			}
+			if e.Color != 0x5865F2 {
+				t.Errorf("color = %#x", e.Color)
+			}
+		})
+	}
+}
+
+func TestTemplateSetMissingTemplate(t *testing.T) {
+	set, err := LoadTemplates(filepath.Join(t.TempDir(), "missing"))
+	if err != nil {
+		t.Fatalf("missing directory: %v", err)
+	}
+	if _, err := set.Render("help", TemplateData{}); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("Render = %v, want ErrTemplateNotFound", err)
+	}
+}
+
+func TestTemplateSetReload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "notice.yaml")
+	write := func(title string, modified time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte("title: "+title+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modified, modified); err != nil {
+			t.Fatal(err)
+		}
+	}
+	title := func(set *TemplateSet) string {
+		t.Helper()
+		b, err := set.Render("notice", TemplateData{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b.Build().Title
+	}
+
+	now := time.Now()
+	write("First", now)
+	set, err := LoadTemplates(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reloaded, err := set.reloadIfChanged(); reloaded || err != nil {
+		t.Errorf("unchanged directory reloaded = %v, %v", reloaded, err)
+	}
+
+	// An older file (e.g. restored from a backup) is still a change
+	write("Restored", now.Add(-time.Hour))
+	if reloaded, err := set.reloadIfChanged(); !reloaded || err != nil {
+		t.Fatalf("reloadIfChanged = %v, %v", reloaded, err)
+	}
+	if got := title(set); got != "Restored" {
+		t.Errorf("title = %q after reload", got)
+	}
+
+	// Invalid files keep the previous templates and aren't retried until they change again
+	write("{{ .Broken", now)
+	if _, err := set.reloadIfChanged(); err == nil {
+		t.Fatal("invalid template was loaded")
+	}
+	if got := title(set); got != "Restored" {
+		t.Errorf("title = %q after a failed reload, want the previous template", got)
+	}
+	if reloaded, err := set.reloadIfChanged(); reloaded || err != nil {
+		t.Errorf("unchanged invalid file reloaded = %v, %v", reloaded, err)
+	}
+
+	// Removing the file removes the template
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if reloaded, err := set.reloadIfChanged(); !reloaded || err != nil {
+		t.Fatalf("reloadIfChanged after removal = %v, %v", reloaded, err)
+	}
+	if _, err := set.Render("notice", TemplateData{}); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("Render after removal = %v, want ErrTemplateNotFound", err)
+	}
+}
@@ -0,0 +1,100 @@
+package i18n
+
+import (
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ============================================
+// Command Localization
+// ============================================
+//
+// Command names and descriptions are looked up under "commands.<name>", following
+// the shape of the command definition:
+//
+//	commands:
+//	  permissions:
+//	    description: 管理此伺服器的 bot 權限
+//	    options:
+//	      grant:
+//	        description: 授予角色或用戶權限等級
+//	        options:
+//	          level:
+//	            description: 權限等級
+//	            choices:
+//	              Server Admin: 伺服器管理員
+//	  User Info:            # Context menu commands use their display name
+//	    name: 用戶資訊
+//
+// The strings in the Go definition stay the default; every catalog that has
+// a key adds it to NameLocalizations / DescriptionLocalizations.
+
+// LocalizeCommand returns a copy of the definition with localizations from every catalog
+func LocalizeCommand(definition *discordgo.ApplicationCommand) *discordgo.ApplicationCommand {
+	def := *definition
+	prefix := "commands." + definition.Name
+
+	def.NameLocalizations = localizations(definition.NameLocalizations, prefix+".name")
+	if def.Type == 0 || def.Type == discordgo.ChatApplicationCommand {
+		def.DescriptionLocalizations = localizations(definition.DescriptionLocalizations, prefix+".description")
+	}
+	def.Options = localizeOptions(definition.Options, prefix)
+	return &def
+}
+
+func localizeOptions(options []*discordgo.ApplicationCommandOption, prefix string) []*discordgo.ApplicationCommandOption {
+	if options == nil {
+		return nil
+	}
+
+	localized := make([]*discordgo.ApplicationCommandOption, len(options))
+	for idx, option := range options {
+		opt := *option
+		key := prefix + ".options." + option.Name
+
+		opt.NameLocalizations = derefLocalizations(localizations(&option.NameLocalizations, key+".name"))
+		opt.DescriptionLocalizations = derefLocalizations(localizations(&option.DescriptionLocalizations, key+".description"))
+		opt.Options = localizeOptions(option.Options, key)
+
+		if option.Choices != nil {
+			opt.Choices = make([]*discordgo.ApplicationCommandOptionChoice, len(option.Choices))
+			for c, choice := range option.Choices {
+				ch := *choice
+				ch.NameLocalizations = derefLocalizations(localizations(&choice.NameLocalizations, key+".choices."+choice.Name))
+				opt.Choices[c] = &ch
+			}
+		}
+		localized[idx] = &opt
+	}
+	return localized
+}
+
+// localizations merges the translations of key from every catalog into existing ones
+// (returns existing unchanged if no catalog has the key)
+func localizations(existing *map[discordgo.Locale]string, key string) *map[discordgo.Locale]string {
+	merged := map[discordgo.Locale]string{}
+	for _, locale := range Locales() {
+		if msg, ok := catalog[locale][key]; ok && strings.TrimSpace(msg.text) != "" {
+			merged[locale] = msg.text
+		}
+	}
+	if len(merged) == 0 {
+		return existing
+	}
+
+	// Localizations set in Go take precedence
+	if existing != nil {
+		for locale, text := range *existing {
+			merged[locale] = text
+		}
+	}
+	return &merged
+}
+
+func derefLocalizations(m *map[discordgo.Locale]string) map[discordgo.Locale]string {
+	if m == nil {
+		return nil
+	}
+	return *m
+}
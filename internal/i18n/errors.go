@@ -0,0 +1,50 @@
+package i18n
+
+import (
+	"errors"
+)
+
+// ============================================
+// Localized Errors
+// ============================================
+//
+// Packages that don't know the user's locale (validators, decoders) return an
+// Error with a catalog key; it is translated where it is shown:
+//
+//	return i18n.Errorf("validation.int_range", min, max)
+//
+//	t.Error(err) // "must be a number from 1 to 10" / "必須是 1 到 10 之間的數字"
+
+// Error is an error whose message is looked up in the catalog when shown
+type Error struct {
+	Key  string
+	Args []interface{} // Errors among the args are translated too
+}
+
+// Errorf creates an Error for key with Sprintf args
+func Errorf(key string, args ...interface{}) error {
+	return &Error{Key: key, Args: args}
+}
+
+// Error returns the message in DefaultLocale (for logs)
+func (e *Error) Error() string {
+	return New().Error(e)
+}
+
+// Error returns the message of err in the translator's language.
+// Errors that are not (or don't wrap) an *Error keep their own message.
+func (t *Translator) Error(err error) string {
+	var e *Error
+	if !errors.As(err, &e) {
+		return err.Error()
+	}
+
+	args := make([]interface{}, len(e.Args))
+	for idx, arg := range e.Args {
+		if argErr, ok := arg.(error); ok {
+			arg = t.Error(argErr)
+		}
+		args[idx] = arg
+	}
+	return t.T(e.Key, args...)
+}
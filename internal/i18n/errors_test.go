@@ -0,0 +1,38 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestTranslatorError(t *testing.T) {
+	inner := Errorf("validation.integer")
+	field := Errorf("validation.field", "Age", inner)
+
+	tests := []struct {
+		name   string
+		locale discordgo.Locale
+		err    error
+		want   string
+	}{
+		{"english", discordgo.EnglishUS, inner, "must be a whole number"},
+		{"chinese", discordgo.ChineseTW, inner, "必須是整數"},
+		{"nested error args", discordgo.ChineseTW, field, "Age必須是整數"},
+		{"wrapped", discordgo.EnglishUS, fmt.Errorf("decode: %w", field), "Age must be a whole number"},
+		{"plain error", discordgo.ChineseTW, errors.New("boom"), "boom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New(tt.locale).Error(tt.err); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := field.Error(); got != "Age must be a whole number" {
+		t.Errorf("field.Error() = %q, want the default locale message", got)
+	}
+}
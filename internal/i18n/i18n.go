@@ -0,0 +1,291 @@
+package i18n
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"discord-bot-template/internal/config"
+
+	"github.com/bwmarrin/discordgo"
+	"gopkg.in/yaml.v3"
+)
+
+// ============================================
+// Message Catalog (多語系)
+// ============================================
+//
+// Messages live in one YAML file per Discord locale (en-US.yaml, zh-TW.yaml, ...).
+// Nested keys are joined with dots, plural messages list their forms:
+//
+//	permissions:
+//	  granted:
+//	    message: "%s now has %s."
+//	  list:
+//	    count:
+//	      one: "{count} grant"
+//	      other: "{count} grants"
+//
+//	t := i18n.New(i.Locale)
+//	t.T("permissions.granted.message", target, level)
+//	t.Plural("permissions.list.count", 3) // "3 grants"
+//
+// The built-in catalogs in internal/i18n/locales are always loaded; files in
+// LOCALES_DIR add locales or override single keys.
+
+// DefaultLocale is the last fallback when a message is missing (see Init)
+var DefaultLocale = discordgo.EnglishUS
+
+//go:embed locales/*.yaml
+var builtin embed.FS
+
+// message is a simple text or a set of plural forms
+type message struct {
+	text   string
+	plural map[string]string // zero, one, two, few, many, other
+}
+
+// catalog maps locale → key → message
+var catalog = map[discordgo.Locale]map[string]*message{}
+
+func init() {
+	if err := loadFS(builtin, "locales"); err != nil {
+		log.Printf("Failed to load built-in locales: %v", err)
+	}
+}
+
+// Init loads the catalogs in LOCALES_DIR on top of the built-in ones and sets the
+// default locale. Call it before the bot starts; a missing directory is not an error.
+func Init(c *config.Config) error {
+	if c.DefaultLocale != "" {
+		DefaultLocale = discordgo.Locale(c.DefaultLocale)
+	}
+	if c.LocalesDir == "" {
+		return nil
+	}
+
+	if _, err := os.Stat(c.LocalesDir); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return loadFS(os.DirFS(c.LocalesDir), ".")
+}
+
+// Locales returns the locales that have a catalog
+func Locales() []discordgo.Locale {
+	locales := make([]discordgo.Locale, 0, len(catalog))
+	for locale := range catalog {
+		locales = append(locales, locale)
+	}
+	sort.Slice(locales, func(a, b int) bool { return locales[a] < locales[b] })
+	return locales
+}
+
+// loadFS merges every *.yaml / *.yml file in dir, named after its locale
+func loadFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, filepath.ToSlash(filepath.Join(dir, entry.Name())))
+		if err != nil {
+			return err
+		}
+		var doc map[string]interface{}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+
+		locale := discordgo.Locale(strings.TrimSuffix(entry.Name(), ext))
+		if catalog[locale] == nil {
+			catalog[locale] = map[string]*message{}
+		}
+		if err := flatten(catalog[locale], "", doc); err != nil {
+			return fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+	}
+	return nil
+}
+
+// flatten turns nested YAML maps into dotted keys
+func flatten(dst map[string]*message, prefix string, doc map[string]interface{}) error {
+	for key, value := range doc {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		switch v := value.(type) {
+		case string:
+			dst[key] = &message{text: v}
+		case int, float64, bool:
+			dst[key] = &message{text: fmt.Sprint(v)}
+		case map[string]interface{}:
+			if forms, ok := pluralForms(v); ok {
+				dst[key] = &message{text: forms["other"], plural: forms}
+				continue
+			}
+			if err := flatten(dst, key, v); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("key %q: expected a string or a map", key)
+		}
+	}
+	return nil
+}
+
+// pluralForms returns the map as plural forms if it only has plural keys (and "other")
+func pluralForms(v map[string]interface{}) (map[string]string, bool) {
+	if _, ok := v["other"]; !ok {
+		return nil, false
+	}
+	forms := make(map[string]string, len(v))
+	for form, text := range v {
+		s, ok := text.(string)
+		if !ok {
+			return nil, false
+		}
+		switch form {
+		case "zero", "one", "two", "few", "many", "other":
+			forms[form] = s
+		default:
+			return nil, false
+		}
+	}
+	return forms, true
+}
+
+// ============================================
+// Translator
+// ============================================
+
+// Translator looks messages up in a chain of locales
+type Translator struct {
+	chain []discordgo.Locale
+}
+
+// New creates a translator trying the given locales in order (empty ones are skipped),
+// then locales of the same language (en-GB → en-US), then DefaultLocale
+func New(locales ...discordgo.Locale) *Translator {
+	t := &Translator{}
+	add := func(locale discordgo.Locale) {
+		if locale == "" || catalog[locale] == nil {
+			return
+		}
+		for _, l := range t.chain {
+			if l == locale {
+				return
+			}
+		}
+		t.chain = append(t.chain, locale)
+	}
+
+	for _, locale := range locales {
+		add(locale)
+		for _, other := range sameLanguage(locale) {
+			add(other)
+		}
+	}
+	add(DefaultLocale)
+	return t
+}
+
+// ForInteraction creates a translator for the user's language, then the guild's
+func ForInteraction(i *discordgo.InteractionCreate) *Translator {
+	var guild discordgo.Locale
+	if i.GuildLocale != nil {
+		guild = *i.GuildLocale
+	}
+	return New(i.Locale, guild)
+}
+
+// Locale returns the locale messages are looked up in first
+func (t *Translator) Locale() discordgo.Locale {
+	if len(t.chain) == 0 {
+		return DefaultLocale
+	}
+	return t.chain[0]
+}
+
+// T returns the message for key formatted with args (fmt.Sprintf), or the key if it is missing
+func (t *Translator) T(key string, args ...interface{}) string {
+	msg, _ := t.lookup(key)
+	if msg == nil {
+		return key
+	}
+	return format(msg.text, args)
+}
+
+// Plural returns the plural form of key for n. "{count}" is replaced by n,
+// args are applied with fmt.Sprintf.
+func (t *Translator) Plural(key string, n int, args ...interface{}) string {
+	msg, locale := t.lookup(key)
+	if msg == nil {
+		return key
+	}
+
+	text := msg.text
+	if msg.plural != nil {
+		form, ok := msg.plural["zero"]
+		if n != 0 || !ok {
+			form, ok = msg.plural[pluralCategory(locale, n)]
+		}
+		if ok {
+			text = form
+		}
+	}
+	return format(strings.ReplaceAll(text, "{count}", strconv.Itoa(n)), args)
+}
+
+// Has reports whether any locale in the chain has the key
+func (t *Translator) Has(key string) bool {
+	msg, _ := t.lookup(key)
+	return msg != nil
+}
+
+func (t *Translator) lookup(key string) (*message, discordgo.Locale) {
+	for _, locale := range t.chain {
+		if msg, ok := catalog[locale][key]; ok {
+			return msg, locale
+		}
+	}
+	return nil, ""
+}
+
+// format applies args only when given, so messages may contain a literal "%"
+func format(text string, args []interface{}) string {
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// sameLanguage returns the loaded locales sharing the language of locale ("en-GB" → "en-US")
+func sameLanguage(locale discordgo.Locale) []discordgo.Locale {
+	var matches []discordgo.Locale
+	for loaded := range catalog {
+		if loaded != locale && language(loaded) == language(locale) {
+			matches = append(matches, loaded)
+		}
+	}
+	sort.Slice(matches, func(a, b int) bool { return matches[a] < matches[b] })
+	return matches
+}
+
+func language(locale discordgo.Locale) string {
+	lang, _, _ := strings.Cut(string(locale), "-")
+	return strings.ToLower(lang)
+}
@@ -0,0 +1,84 @@
+package i18n
+
+import "github.com/bwmarrin/discordgo"
+
+// ============================================
+// Plural Rules (CLDR, integers only)
+// ============================================
+
+// pluralCategory returns the CLDR plural category of n in a locale's language
+func pluralCategory(locale discordgo.Locale, n int) string {
+	if n < 0 {
+		n = -n
+	}
+	mod10, mod100 := n%10, n%100
+
+	switch language(locale) {
+	// No plural forms
+	case "zh", "ja", "ko", "th", "vi", "id":
+		return "other"
+
+	// 0 and 1 are singular
+	case "fr", "hi":
+		if n <= 1 {
+			return "one"
+		}
+		return "other"
+	case "pt":
+		// Brazilian Portuguese treats 0 as singular, European Portuguese doesn't
+		if n == 1 || (n == 0 && locale == discordgo.PortugueseBR) {
+			return "one"
+		}
+		return "other"
+
+	// Slavic
+	case "ru", "uk", "hr", "sr":
+		switch {
+		case mod10 == 1 && mod100 != 11:
+			return "one"
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return "few"
+		case language(locale) == "hr" || language(locale) == "sr":
+			return "other" // "many" is only for fractions there
+		}
+		return "many"
+	case "pl":
+		switch {
+		case n == 1:
+			return "one"
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return "few"
+		}
+		return "many"
+	case "cs", "sk":
+		switch {
+		case n == 1:
+			return "one"
+		case n >= 2 && n <= 4:
+			return "few"
+		}
+		return "other"
+	case "lt":
+		switch {
+		case mod10 == 1 && (mod100 < 11 || mod100 > 19):
+			return "one"
+		case mod10 >= 2 && (mod100 < 11 || mod100 > 19):
+			return "few"
+		}
+		return "other"
+	case "ro":
+		switch {
+		case n == 1:
+			return "one"
+		case n == 0 || (mod100 >= 2 && mod100 <= 19):
+			return "few"
+		}
+		return "other"
+	}
+
+	// English, German, Spanish, ... and unknown languages
+	if n == 1 {
+		return "one"
+	}
+	return "other"
+}
@@ -0,0 +1,59 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestPluralCategory(t *testing.T) {
+	tests := []struct {
+		locale discordgo.Locale
+		want   map[int]string // n → category
+	}{
+		{discordgo.EnglishUS, map[int]string{0: "other", 1: "one", 2: "other", 11: "other", -1: "one"}},
+		{discordgo.ChineseTW, map[int]string{0: "other", 1: "other", 2: "other"}},
+		{discordgo.Japanese, map[int]string{1: "other"}},
+		{discordgo.French, map[int]string{0: "one", 1: "one", 2: "other"}},
+		{discordgo.PortugueseBR, map[int]string{0: "one", 1: "one", 2: "other"}},
+		{discordgo.Russian, map[int]string{
+			1: "one", 21: "one", 11: "many", 2: "few", 4: "few", 22: "few", 12: "many", 14: "many", 5: "many", 0: "many", 111: "many",
+		}},
+		{discordgo.Ukrainian, map[int]string{1: "one", 3: "few", 5: "many"}},
+		{discordgo.Croatian, map[int]string{1: "one", 21: "one", 11: "other", 2: "few", 12: "other", 5: "other", 0: "other"}},
+		{discordgo.Polish, map[int]string{1: "one", 21: "many", 2: "few", 22: "few", 12: "many", 5: "many", 0: "many"}},
+		{discordgo.Czech, map[int]string{1: "one", 2: "few", 4: "few", 5: "other", 22: "other"}},
+		{discordgo.Lithuanian, map[int]string{1: "one", 21: "one", 11: "other", 2: "few", 9: "few", 12: "other", 10: "other", 20: "other"}},
+		{discordgo.Romanian, map[int]string{1: "one", 0: "few", 2: "few", 19: "few", 102: "few", 20: "other", 101: "other"}},
+		{discordgo.Locale("xx-XX"), map[int]string{1: "one", 2: "other"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.locale), func(t *testing.T) {
+			for n, want := range tt.want {
+				if got := pluralCategory(tt.locale, n); got != want {
+					t.Errorf("pluralCategory(%s, %d) = %q, want %q", tt.locale, n, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPlural(t *testing.T) {
+	tests := []struct {
+		locale discordgo.Locale
+		n      int
+		want   string
+	}{
+		{discordgo.EnglishUS, 1, "1 grant"},
+		{discordgo.EnglishUS, 3, "3 grants"},
+		{discordgo.ChineseTW, 1, "共 1 項授予"},
+		{discordgo.EnglishGB, 2, "2 grants"}, // Same language falls back to en-US
+	}
+
+	for _, tt := range tests {
+		if got := New(tt.locale).Plural("permissions.list.count", tt.n); got != tt.want {
+			t.Errorf("Plural(%s, %d) = %q, want %q", tt.locale, tt.n, got, tt.want)
+		}
+	}
+}
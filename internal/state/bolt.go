@@ -0,0 +1,148 @@
+package state
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var stateBucket = []byte("state")
+
+// BoltStore keeps state in a BoltDB file so it survives restarts.
+// Each value is stored as an 8-byte expiry (unix nanoseconds, 0 = never) followed by the JSON.
+type BoltStore struct {
+	db *bolt.DB
+
+	stop chan struct{}
+	once sync.Once
+}
+
+// OpenBoltStore opens (or creates) a BoltDB state file
+func OpenBoltStore(path string) (*BoltStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(stateBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	s := &BoltStore{
+		db:   db,
+		stop: make(chan struct{}),
+	}
+	if err := s.sweep(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	go janitor(s.stop, s.sweep)
+	return s, nil
+}
+
+// Get decodes the value for key into dst
+func (s *BoltStore) Get(key string, dst interface{}) (bool, error) {
+	var data []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		raw := tx.Bucket(stateBucket).Get([]byte(key))
+		if raw == nil {
+			return nil
+		}
+		expires, value := decodeBoltValue(raw)
+		if expired(expires, time.Now()) {
+			return nil
+		}
+		// raw is only valid inside the transaction
+		data = append([]byte(nil), value...)
+		return nil
+	})
+	if err != nil || data == nil {
+		return false, err
+	}
+	return true, json.Unmarshal(data, dst)
+}
+
+// Set stores value for key
+func (s *BoltStore) Set(key string, value interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	raw := make([]byte, 8+len(data))
+	if expires := expiry(ttl); !expires.IsZero() {
+		binary.BigEndian.PutUint64(raw, uint64(expires.UnixNano()))
+	}
+	copy(raw[8:], data)
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(stateBucket).Put([]byte(key), raw)
+	})
+}
+
+// Delete removes key
+func (s *BoltStore) Delete(key string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(stateBucket).Delete([]byte(key))
+	})
+}
+
+// Close stops the expiry sweeper and closes the file
+func (s *BoltStore) Close() error {
+	var err error
+	s.once.Do(func() {
+		close(s.stop)
+		err = s.db.Close()
+	})
+	return err
+}
+
+// sweep removes expired entries
+func (s *BoltStore) sweep() error {
+	now := time.Now()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		var keys [][]byte
+		err := tx.Bucket(stateBucket).ForEach(func(k, v []byte) error {
+			if expires, _ := decodeBoltValue(v); expired(expires, now) {
+				keys = append(keys, append([]byte(nil), k...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, k := range keys {
+			if err := tx.Bucket(stateBucket).Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func decodeBoltValue(raw []byte) (time.Time, []byte) {
+	if len(raw) < 8 {
+		return time.Time{}, raw
+	}
+	var expires time.Time
+	if n := binary.BigEndian.Uint64(raw); n != 0 {
+		expires = time.Unix(0, int64(n))
+	}
+	return expires, raw[8:]
+}
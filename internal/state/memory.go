@@ -0,0 +1,84 @@
+package state
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps state in memory; everything is lost on restart
+type MemoryStore struct {
+	mu      sync.RWMutex
+	entries map[string]memoryEntry
+
+	stop chan struct{}
+	once sync.Once
+}
+
+type memoryEntry struct {
+	data    []byte
+	expires time.Time
+}
+
+// NewMemoryStore creates an in-memory store
+func NewMemoryStore() *MemoryStore {
+	s := &MemoryStore{
+		entries: make(map[string]memoryEntry),
+		stop:    make(chan struct{}),
+	}
+	go janitor(s.stop, s.sweep)
+	return s
+}
+
+// Get decodes the value for key into dst
+func (s *MemoryStore) Get(key string, dst interface{}) (bool, error) {
+	s.mu.RLock()
+	entry, ok := s.entries[key]
+	s.mu.RUnlock()
+
+	if !ok || expired(entry.expires, time.Now()) {
+		return false, nil
+	}
+	return true, json.Unmarshal(entry.data, dst)
+}
+
+// Set stores value for key
+func (s *MemoryStore) Set(key string, value interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = memoryEntry{data: data, expires: expiry(ttl)}
+	return nil
+}
+
+// Delete removes key
+func (s *MemoryStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
+
+// Close stops the expiry sweeper
+func (s *MemoryStore) Close() error {
+	s.once.Do(func() { close(s.stop) })
+	return nil
+}
+
+// sweep removes expired entries
+func (s *MemoryStore) sweep() error {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, entry := range s.entries {
+		if expired(entry.expires, now) {
+			delete(s.entries, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"discord-bot-template/internal/config"
+)
+
+// ============================================
+// State Store (元件 / 訊息狀態)
+// ============================================
+
+// Store keeps short-lived state keyed by message ID, interaction token or any
+// other string. Values are stored as JSON, so every backend behaves the same.
+type Store interface {
+	// Get decodes the value for key into dst, returning false if it is missing or expired
+	Get(key string, dst interface{}) (bool, error)
+	// Set stores value for key; ttl <= 0 means it never expires
+	Set(key string, value interface{}, ttl time.Duration) error
+	// Delete removes key (no error if it doesn't exist)
+	Delete(key string) error
+	// Close releases the backend's resources
+	Close() error
+}
+
+// Backend names for STATE_BACKEND
+const (
+	BackendMemory = "memory"
+	BackendBolt   = "bolt"
+)
+
+// sweepInterval is how often expired entries are removed
+const sweepInterval = time.Minute
+
+var store Store = NewMemoryStore()
+
+// Default returns the configured store (memory until Init is called)
+func Default() Store {
+	return store
+}
+
+// Init opens the store selected by the config
+func Init(c *config.Config) error {
+	s, err := Open(c.StateBackend, c.StateFile)
+	if err != nil {
+		return err
+	}
+
+	old := store
+	store = s
+	return old.Close()
+}
+
+// Open creates a store for a backend name
+func Open(backend, path string) (Store, error) {
+	switch backend {
+	case "", BackendMemory:
+		return NewMemoryStore(), nil
+	case BackendBolt:
+		if path == "" {
+			return nil, errors.New("STATE_FILE is required for the bolt backend")
+		}
+		return OpenBoltStore(path)
+	}
+	return nil, fmt.Errorf("unknown state backend %q", backend)
+}
+
+// Close closes the default store
+func Close() error {
+	return store.Close()
+}
+
+// ============================================
+// Keys
+// ============================================
+
+// MessageKey returns the key for state attached to a message
+func MessageKey(messageID string) string {
+	return "message:" + messageID
+}
+
+// InteractionKey returns the key for state attached to an interaction token
+func InteractionKey(token string) string {
+	return "interaction:" + token
+}
+
+// expiry converts a TTL into an absolute expiry time (zero = never)
+func expiry(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(ttl)
+}
+
+func expired(expires time.Time, now time.Time) bool {
+	return !expires.IsZero() && now.After(expires)
+}
+
+// janitor calls sweep every interval until stop is closed
+func janitor(stop <-chan struct{}, sweep func() error) {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := sweep(); err != nil {
+				log.Printf("Failed to sweep expired state: %v", err)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
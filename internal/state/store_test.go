@@ -0,0 +1,130 @@
+package state
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+type testValue struct {
+	Name  string
+	Count int
+}
+
+// backends opens every store implementation on a fresh file
+func backends(t *testing.T) map[string]Store {
+	t.Helper()
+	boltStore, err := OpenBoltStore(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	memoryStore := NewMemoryStore()
+	t.Cleanup(func() {
+		memoryStore.Close()
+		boltStore.Close()
+	})
+	return map[string]Store{
+		BackendMemory: memoryStore,
+		BackendBolt:   boltStore,
+	}
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	for name, s := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			want := testValue{Name: "poll", Count: 3}
+			if err := s.Set("key", want, time.Hour); err != nil {
+				t.Fatal(err)
+			}
+
+			var got testValue
+			if ok, err := s.Get("key", &got); !ok || err != nil {
+				t.Fatalf("Get = %v, %v", ok, err)
+			}
+			if got != want {
+				t.Errorf("Get = %+v, want %+v", got, want)
+			}
+
+			if err := s.Delete("key"); err != nil {
+				t.Fatal(err)
+			}
+			if ok, _ := s.Get("key", &got); ok {
+				t.Errorf("deleted key still found")
+			}
+			if ok, _ := s.Get("missing", &got); ok {
+				t.Errorf("missing key found")
+			}
+		})
+	}
+}
+
+func TestStoreExpiry(t *testing.T) {
+	for name, s := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			if err := s.Set("short", testValue{}, time.Millisecond); err != nil {
+				t.Fatal(err)
+			}
+			for _, ttl := range []time.Duration{0, -time.Second} {
+				if err := s.Set("forever", testValue{Count: int(ttl)}, ttl); err != nil {
+					t.Fatal(err)
+				}
+				time.Sleep(5 * time.Millisecond)
+
+				var got testValue
+				if ok, _ := s.Get("forever", &got); !ok {
+					t.Errorf("ttl %v expired, want it kept", ttl)
+				}
+			}
+
+			var got testValue
+			if ok, _ := s.Get("short", &got); ok {
+				t.Errorf("expired key still found")
+			}
+		})
+	}
+}
+
+func TestBoltStoreSweepAndReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+	s, err := OpenBoltStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("kept", testValue{Name: "kept"}, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("expired", testValue{}, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reopening sweeps expired entries and keeps the rest
+	s, err = OpenBoltStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	var got testValue
+	if ok, err := s.Get("kept", &got); !ok || err != nil || got.Name != "kept" {
+		t.Errorf("Get after reopen = %v, %v, %+v", ok, err, got)
+	}
+	entries := 0
+	err = s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(stateBucket).ForEach(func(k, v []byte) error {
+			entries++
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entries != 1 {
+		t.Errorf("%d entries after sweep, want 1", entries)
+	}
+}